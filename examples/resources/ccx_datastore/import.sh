@@ -0,0 +1,5 @@
+# import by datastore ID
+terraform import ccx_datastore.luna 00000000-0000-0000-0000-000000000001
+
+# import by datastore name
+terraform import ccx_datastore.luna name:luna
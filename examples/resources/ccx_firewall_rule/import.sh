@@ -0,0 +1,2 @@
+# import by <datastore_id>/<cidr>
+terraform import ccx_firewall_rule.office 00000000-0000-0000-0000-000000000001/10.0.0.0/16
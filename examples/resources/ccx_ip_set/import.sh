@@ -0,0 +1,2 @@
+# import by name
+terraform import ccx_ip_set.office office
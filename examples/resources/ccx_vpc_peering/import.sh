@@ -0,0 +1,2 @@
+# import by <vpc_id>/<peering_id>
+terraform import ccx_vpc_peering.app 00000000-0000-0000-0000-000000000001/00000000-0000-0000-0000-000000000002
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+	"github.com/severalnines/terraform-provider-ccx/internal/export"
+)
+
+func envOr(key, value string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+
+	return value
+}
+
+// runExport writes terraform configuration, with import blocks, for the resources which already exist in CCX
+func runExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+
+	out := fs.String("out", ".", "directory to write the generated .tf files to")
+	force := fs.Bool("force", false, "overwrite existing files")
+	clientID := fs.String("client-id", envOr("CCX_CLIENT_ID", ""), "OAuth client ID, defaults to $CCX_CLIENT_ID")
+	clientSecret := fs.String("client-secret", envOr("CCX_CLIENT_SECRET", ""), "OAuth client secret, defaults to $CCX_CLIENT_SECRET")
+	baseURL := fs.String("base-url", envOr("CCX_BASE_URL", ccx.DefaultBaseURL), "CCX base URL, defaults to $CCX_BASE_URL")
+	timeout := fs.Duration("timeout", time.Minute*10, "timeout for the whole export")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *clientID == "" || *clientSecret == "" {
+		return errors.New("client id and client secret are required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	httpClient := ccx.NewHTTPClient(strings.Trim(*baseURL, "/"), *clientID, *clientSecret)
+
+	contentSvc, err := ccx.NewContentClient(httpClient)
+	if err != nil {
+		return err
+	}
+
+	datastoreSvc, err := ccx.NewDatastoresClient(httpClient, *timeout, contentSvc)
+	if err != nil {
+		return err
+	}
+
+	e := export.NewExporter(datastoreSvc, ccx.NewVPCsClient(httpClient), ccx.NewParameterGroupsClient(httpClient))
+
+	files, err := e.Export(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		path := filepath.Join(*out, name)
+
+		if _, err := os.Stat(path); err == nil && !*force {
+			return fmt.Errorf("%s already exists, use -force to overwrite", path)
+		}
+
+		if err := os.WriteFile(path, files[name], 0o644); err != nil {
+			return err
+		}
+
+		fmt.Println("wrote", path)
+	}
+
+	return nil
+}
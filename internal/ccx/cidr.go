@@ -0,0 +1,76 @@
+package ccx
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// CanonicalCIDR returns the canonical form of an IPv4 or IPv6 CIDR, as stored by CCX
+// surrounding spaces are removed, host bits are cleared, and a bare IP is converted to /32 or /128
+func CanonicalCIDR(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	if !strings.Contains(s, "/") {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return "", fmt.Errorf("invalid CIDR %q: %w", s, err)
+		}
+
+		if addr.Zone() != "" {
+			return "", fmt.Errorf("invalid CIDR %q: zones are not supported", s)
+		}
+
+		return netip.PrefixFrom(addr, addr.BitLen()).String(), nil
+	}
+
+	p, err := netip.ParsePrefix(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid CIDR %q: %w", s, err)
+	}
+
+	return p.Masked().String(), nil
+}
+
+// sameCIDR reports whether both are the same CIDR, values which are not CIDRs are compared as they are
+func sameCIDR(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	ca, err := CanonicalCIDR(a)
+	if err != nil {
+		return false
+	}
+
+	cb, err := CanonicalCIDR(b)
+	if err != nil {
+		return false
+	}
+
+	return ca == cb
+}
+
+// CIDRsOverlap reports whether the CIDRs share any address
+func CIDRsOverlap(a, b string) (bool, error) {
+	pa, err := parseCIDR(a)
+	if err != nil {
+		return false, err
+	}
+
+	pb, err := parseCIDR(b)
+	if err != nil {
+		return false, err
+	}
+
+	return pa.Overlaps(pb), nil
+}
+
+func parseCIDR(s string) (netip.Prefix, error) {
+	c, err := CanonicalCIDR(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	return netip.ParsePrefix(c)
+}
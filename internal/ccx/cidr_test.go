@@ -0,0 +1,73 @@
+package ccx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalCIDR(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "10.0.0.0/8", want: "10.0.0.0/8"},
+		{in: "10.0.0.0/8 ", want: "10.0.0.0/8"},
+		{in: " 10.0.0.5", want: "10.0.0.5/32"},
+		{in: "10.1.2.3/16", want: "10.1.0.0/16"},
+		{in: "0.0.0.0/0", want: "0.0.0.0/0"},
+		{in: "2001:db8::1", want: "2001:db8::1/128"},
+		{in: "2001:DB8:0:0::/32", want: "2001:db8::/32"},
+		{in: "2001:db8:1234::/32", want: "2001:db8::/32"},
+		{in: "::/0", want: "::/0"},
+		{in: "", wantErr: true},
+		{in: "10.0.0.256", wantErr: true},
+		{in: "10.0.0.0/33", wantErr: true},
+		{in: "2001:db8::/129", wantErr: true},
+		{in: "fe80::1%eth0", wantErr: true},
+		{in: "example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := CanonicalCIDR(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCIDRsOverlap(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		want    bool
+		wantErr bool
+	}{
+		{a: "10.0.0.0/16", b: "10.0.0.0/16", want: true},
+		{a: "10.0.0.0/16", b: "10.0.128.0/24", want: true},
+		{a: "10.0.128.0/24", b: "10.0.0.0/16", want: true},
+		{a: "10.0.0.0/16", b: "10.1.0.0/16", want: false},
+		{a: "10.0.0.0/16", b: "10.0.0.5", want: true},
+		{a: "10.0.0.0/16", b: "2001:db8::/32", want: false},
+		{a: "10.0.0.0/16", b: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			got, err := CIDRsOverlap(tt.a, tt.b)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package ccx
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Connection holds connection strings for the datastore, in formats used by different clients
+// the multi host formats list every host of the datastore, with the primary first, for client-side failover
+type Connection struct {
+	PrimaryJdbcURL    string
+	ReplicaJdbcURL    string
+	PrimaryConninfo   string
+	ReplicaConninfo   string
+	MultiHostDsn      string
+	MultiHostJdbcURL  string
+	MultiHostConninfo string
+}
+
+func hostPort(host string, port int) string {
+	if !strings.Contains(host, ":") {
+		host += ":" + strconv.Itoa(port)
+	}
+
+	return host
+}
+
+// JdbcURL returns a JDBC URL for the vendor, or an empty string if the vendor has no JDBC driver or does not support multiple hosts
+// TLS is required when ssl is enabled
+func JdbcURL(vendor string, hosts []string, port int, username, password, dbname string, ssl bool) string {
+	if len(hosts) == 0 {
+		return ""
+	}
+
+	addrs := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		addrs = append(addrs, hostPort(h, port))
+	}
+
+	q := "?user=" + url.QueryEscape(username) + "&password=" + url.QueryEscape(password)
+
+	switch vendor {
+	case "mysql", "percona":
+		if ssl {
+			q += "&sslMode=REQUIRED"
+		}
+
+		return "jdbc:mysql://" + strings.Join(addrs, ",") + "/" + dbname + q
+	case "mariadb":
+		// the server certificate is verified, the CA certificate of the datastore is passed in serverSslCert
+		if ssl {
+			q += "&sslMode=verify-full"
+		}
+
+		return "jdbc:mariadb://" + strings.Join(addrs, ",") + "/" + dbname + q
+	case "postgres", "pgsql":
+		if len(addrs) > 1 {
+			q += "&targetServerType=primary"
+		}
+
+		if ssl {
+			q += "&sslmode=require"
+		}
+
+		return "jdbc:postgresql://" + strings.Join(addrs, ",") + "/" + dbname + q
+	case "microsoft":
+		if len(addrs) > 1 {
+			return ""
+		}
+
+		s := "jdbc:sqlserver://" + addrs[0] + ";databaseName=" + sqlServerJdbcValue(dbname) + ";user=" + sqlServerJdbcValue(username) + ";password=" + sqlServerJdbcValue(password)
+		if ssl {
+			s += ";encrypt=true"
+		}
+
+		return s
+	}
+
+	return ""
+}
+
+// Conninfo returns a libpq keyword/value connection string, for postgres only
+func Conninfo(vendor string, hosts []string, port int, username, password, dbname string, ssl bool) string {
+	if len(hosts) == 0 {
+		return ""
+	}
+
+	switch vendor {
+	case "postgres", "pgsql":
+	default:
+		return ""
+	}
+
+	names := make([]string, 0, len(hosts))
+	ports := make([]string, 0, len(hosts))
+
+	for _, h := range hosts {
+		name, p, _ := strings.Cut(hostPort(h, port), ":")
+		names = append(names, name)
+		ports = append(ports, p)
+	}
+
+	s := "host=" + conninfoValue(strings.Join(names, ",")) +
+		" port=" + conninfoValue(strings.Join(ports, ",")) +
+		" dbname=" + conninfoValue(dbname) +
+		" user=" + conninfoValue(username) +
+		" password=" + conninfoValue(password)
+
+	if len(hosts) > 1 {
+		s += " target_session_attrs=read-write"
+	}
+
+	if ssl {
+		s += " sslmode=require"
+	}
+
+	return s
+}
+
+// conninfoValue quotes the value when needed, see https://www.postgresql.org/docs/current/libpq-connect.html#LIBPQ-CONNSTRING-KEYWORD-VALUE
+func conninfoValue(s string) string {
+	if s != "" && !strings.ContainsAny(s, ` '\`) {
+		return s
+	}
+
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+
+	return "'" + s + "'"
+}
+
+// sqlServerJdbcValue encloses the value in braces when needed, see https://learn.microsoft.com/en-us/sql/connect/jdbc/building-the-connection-url
+func sqlServerJdbcValue(s string) string {
+	if !strings.ContainsAny(s, ";{}") && strings.TrimSpace(s) == s {
+		return s
+	}
+
+	return "{" + strings.ReplaceAll(s, "}", "}}") + "}"
+}
+
+// adoValue quotes the value of an ADO.NET connection string when needed, doubling the quotes inside it
+func adoValue(s string) string {
+	if !strings.ContainsAny(s, `;"'`) && strings.TrimSpace(s) == s {
+		return s
+	}
+
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// userinfo returns the credentials of a URL, with all but the unreserved characters percent-encoded,
+// so that e.g. "@", ":" or "&" in the password do not break the URL
+func userinfo(username, password string) string {
+	return escapeUserinfo(username) + ":" + escapeUserinfo(password)
+}
+
+func escapeUserinfo(s string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+// MultiHostDsn returns a DSN listing every host, or an empty string if the vendor does not support multiple hosts
+func MultiHostDsn(vendor string, hosts []string, port int, username, password, dbname string, ssl bool) string {
+	if len(hosts) == 0 {
+		return ""
+	}
+
+	addrs := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		addrs = append(addrs, hostPort(h, port))
+	}
+
+	switch vendor {
+	case "mysql", "mariadb", "percona":
+		var q string
+		if ssl {
+			q = "?ssl-mode=REQUIRED"
+		}
+
+		return "mysql://" + userinfo(username, password) + "@" + strings.Join(addrs, ",") + "/" + dbname + q
+	case "postgres", "pgsql":
+		q := "?target_session_attrs=read-write"
+		if ssl {
+			q += "&sslmode=require"
+		}
+
+		return "postgres://" + userinfo(username, password) + "@" + strings.Join(addrs, ",") + "/" + dbname + q
+	}
+
+	return ""
+}
+
+// hostAddresses returns the addresses of the hosts, the primary first
+func hostAddresses(hosts []Host) []string {
+	var primary, rest []string
+
+	for _, h := range hosts {
+		if h.Hostname == "" {
+			continue
+		}
+
+		addr := h.Hostname
+		if h.Port != 0 {
+			addr = hostPort(h.Hostname, h.Port)
+		}
+
+		if h.IsPrimary() {
+			primary = append(primary, addr)
+		} else {
+			rest = append(rest, addr)
+		}
+	}
+
+	return append(primary, rest...)
+}
+
+func connection(c Datastore, port int) Connection {
+	var conn Connection
+
+	if c.PrimaryUrl != "" {
+		conn.PrimaryJdbcURL = JdbcURL(c.DBVendor, []string{c.PrimaryUrl}, port, c.Username, c.Password, c.DbName, c.SSLEnabled)
+		conn.PrimaryConninfo = Conninfo(c.DBVendor, []string{c.PrimaryUrl}, port, c.Username, c.Password, c.DbName, c.SSLEnabled)
+	}
+
+	if c.ReplicaUrl != "" {
+		conn.ReplicaJdbcURL = JdbcURL(c.DBVendor, []string{c.ReplicaUrl}, port, c.Username, c.Password, c.DbName, c.SSLEnabled)
+		conn.ReplicaConninfo = Conninfo(c.DBVendor, []string{c.ReplicaUrl}, port, c.Username, c.Password, c.DbName, c.SSLEnabled)
+	}
+
+	hosts := hostAddresses(c.Hosts)
+
+	conn.MultiHostDsn = MultiHostDsn(c.DBVendor, hosts, port, c.Username, c.Password, c.DbName, c.SSLEnabled)
+	conn.MultiHostJdbcURL = JdbcURL(c.DBVendor, hosts, port, c.Username, c.Password, c.DbName, c.SSLEnabled)
+	conn.MultiHostConninfo = Conninfo(c.DBVendor, hosts, port, c.Username, c.Password, c.DbName, c.SSLEnabled)
+
+	return conn
+}
@@ -132,3 +132,53 @@ func (svc *ContentClient) VolumeTypes(ctx context.Context, cloud string) ([]stri
 
 	return ls, nil
 }
+
+type volumeLimitsRange struct {
+	Min uint64 `json:"min"`
+	Max uint64 `json:"max"`
+}
+
+type volumeLimitsResponse struct {
+	Instance struct {
+		VolumeTypes map[string][]struct {
+			Code       string             `json:"code"`
+			IOPS       *volumeLimitsRange `json:"iops"`
+			Throughput *volumeLimitsRange `json:"throughput"`
+		} `json:"volume_types"`
+	} `json:"instance"`
+}
+
+// VolumeLimits returns the allowed iops and throughput per volume type of the cloud
+func (svc *ContentClient) VolumeLimits(ctx context.Context, cloud string) (map[string]VolumeLimits, error) {
+	var rs volumeLimitsResponse
+
+	err := svc.client.Get(ctx, "/api/content/api/v1/deploy-wizard", &rs)
+	if err != nil {
+		return nil, err
+	}
+
+	vt, ok := rs.Instance.VolumeTypes[cloud]
+	if !ok {
+		return nil, fmt.Errorf("no volume types found for cloud %q", cloud)
+	}
+
+	m := make(map[string]VolumeLimits, len(vt))
+
+	for _, v := range vt {
+		var l VolumeLimits
+
+		if v.IOPS != nil {
+			l.MinIOPS = v.IOPS.Min
+			l.MaxIOPS = v.IOPS.Max
+		}
+
+		if v.Throughput != nil {
+			l.MinThroughput = v.Throughput.Min
+			l.MaxThroughput = v.Throughput.Max
+		}
+
+		m[v.Code] = l
+	}
+
+	return m, nil
+}
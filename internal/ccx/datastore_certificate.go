@@ -0,0 +1,20 @@
+package ccx
+
+import (
+	"context"
+)
+
+type getCACertificateResponse struct {
+	CACertificate string `json:"ca_certificate"`
+}
+
+// GetCACertificate returns the PEM encoded CA certificate, which signed the server certificates of the datastore
+func (svc *DatastoresClient) GetCACertificate(ctx context.Context, storeID string) (string, error) {
+	var rs getCACertificateResponse
+
+	if err := svc.client.Get(ctx, "/api/deployment/v3/data-stores/"+storeID+"/ca-certificate", &rs); err != nil {
+		return "", err
+	}
+
+	return rs.CACertificate, nil
+}
@@ -21,10 +21,12 @@ type createStoreCloud struct {
 }
 
 type createStoreInstance struct {
-	InstanceSize string `json:"instance_size"` // "Tiny" ... "2X-Large"
-	VolumeType   string `json:"volume_type"`
-	VolumeSize   uint64 `json:"volume_size"`
-	VolumeIOPS   uint64 `json:"volume_iops"`
+	InstanceSize     string            `json:"instance_size"` // "Tiny" ... "2X-Large"
+	VolumeType       string            `json:"volume_type"`
+	VolumeSize       uint64            `json:"volume_size"`
+	VolumeIOPS       uint64            `json:"volume_iops"`
+	VolumeThroughput uint64            `json:"volume_throughput,omitempty"`
+	StorageAutoscale *storageAutoscale `json:"storage_autoscale,omitempty"`
 }
 
 type createStoreNetwork struct {
@@ -65,10 +67,19 @@ func createRequestFromDatastore(c Datastore) createStoreRequest {
 	}
 
 	instance := createStoreInstance{
-		InstanceSize: c.InstanceSize,
-		VolumeType:   c.VolumeType,
-		VolumeSize:   volumeSize,
-		VolumeIOPS:   c.VolumeIOPS,
+		InstanceSize:     c.InstanceSize,
+		VolumeType:       c.VolumeType,
+		VolumeSize:       volumeSize,
+		VolumeIOPS:       c.VolumeIOPS,
+		VolumeThroughput: c.VolumeThroughput,
+	}
+
+	if a := c.StorageAutoscale; a != nil {
+		instance.StorageAutoscale = &storageAutoscale{
+			Enabled:      a.Enabled,
+			MaxSize:      a.MaxSize,
+			ThresholdPct: a.ThresholdPct,
+		}
 	}
 
 	networkType := "public"
@@ -26,6 +26,61 @@ type getFirewallsResponse []struct {
 	} `json:"ports"`
 }
 
+// states of firewall rules reported by FirewallRulesError
+const (
+	FirewallRuleCreated     = "created"
+	FirewallRuleNotCreated  = "not created"
+	FirewallRuleDeleted     = "deleted"
+	FirewallRuleNotDeleted  = "not deleted"
+	FirewallRuleKept        = "kept, as creating other rules failed"
+	FirewallRuleRestored    = "restored, as creating its replacement failed"
+	FirewallRuleNotRestored = "deleted, restoring it failed"
+)
+
+// FirewallRuleResult is the state of a rule after SetFirewallRules changed it, Err is set when changing it failed
+type FirewallRuleResult struct {
+	Rule  FirewallRule
+	State string
+	Err   error
+}
+
+func (r FirewallRuleResult) failed() bool {
+	return r.Err != nil || r.State == FirewallRuleKept
+}
+
+// FirewallRulesError lists the state of every rule SetFirewallRules changed, when any change failed
+type FirewallRulesError struct {
+	Results []FirewallRuleResult
+}
+
+func (e *FirewallRulesError) Error() string {
+	var b strings.Builder
+
+	b.WriteString("firewall rules changed only partially:")
+
+	for _, r := range e.Results {
+		fmt.Fprintf(&b, "\n  rule (source=%s, description=%s): %s", r.Rule.Source, r.Rule.Description, r.State)
+
+		if r.Err != nil {
+			fmt.Fprintf(&b, ": %s", r.Err)
+		}
+	}
+
+	return b.String()
+}
+
+func (e *FirewallRulesError) Unwrap() []error {
+	var errs []error
+
+	for _, r := range e.Results {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		}
+	}
+
+	return errs
+}
+
 func (svc *DatastoresClient) GetFirewallRules(ctx context.Context, storeID string) ([]FirewallRule, error) {
 	var rs getFirewallsResponse
 
@@ -35,10 +90,18 @@ func (svc *DatastoresClient) GetFirewallRules(ctx context.Context, storeID strin
 
 	ls := make([]FirewallRule, 0, len(rs))
 	for _, r := range rs {
-		ls = append(ls, FirewallRule{
+		f := FirewallRule{
 			Source:      r.Source,
 			Description: r.Description,
-		})
+		}
+
+		for _, p := range r.Ports {
+			f.Ports = append(f.Ports, p.Port)
+		}
+
+		slices.Sort(f.Ports)
+
+		ls = append(ls, f)
 	}
 
 	slices.SortStableFunc(ls, func(a, b FirewallRule) int {
@@ -48,25 +111,16 @@ func (svc *DatastoresClient) GetFirewallRules(ctx context.Context, storeID strin
 	return ls, nil
 }
 
+// firewallsDiff returns the rules to create and to delete, a rule whose ports changed is deleted and created again
 func firewallsDiff(have, want []FirewallRule) (create, del []FirewallRule) {
-	haveM := make(map[FirewallRule]struct{}, len(have))
-	for _, f := range have {
-		haveM[f] = struct{}{}
-	}
-
 	for _, f := range want {
-		if _, ok := haveM[f]; !ok {
+		if !slices.ContainsFunc(have, f.Matches) {
 			create = append(create, f)
 		}
 	}
 
-	wantM := make(map[FirewallRule]struct{}, len(want))
-	for _, f := range want {
-		wantM[f] = struct{}{}
-	}
-
 	for _, f := range have {
-		if _, ok := wantM[f]; !ok {
+		if !slices.ContainsFunc(want, f.Matches) {
 			del = append(del, f)
 		}
 	}
@@ -75,6 +129,11 @@ func firewallsDiff(have, want []FirewallRule) (create, del []FirewallRule) {
 }
 
 func (svc *DatastoresClient) CreateFirewallRule(ctx context.Context, storeID string, firewall FirewallRule) error {
+	// CCX opens all ports for a rule without ports
+	if firewall.OpensAllPorts() {
+		firewall.Ports = nil
+	}
+
 	_, err := svc.client.Do(ctx, http.MethodPost, "/api/firewall/api/v1/firewall/"+storeID, firewall)
 	if err != nil {
 		return err
@@ -83,25 +142,11 @@ func (svc *DatastoresClient) CreateFirewallRule(ctx context.Context, storeID str
 	return nil
 }
 
-func (svc *DatastoresClient) CreateFirewallRules(ctx context.Context, storeID string, firewalls []FirewallRule) error {
-	var errs []error
-
-	for _, f := range firewalls {
-		err := svc.CreateFirewallRule(ctx, storeID, f)
-		if err != nil {
-			errs = append(errs, fmt.Errorf("creating rule (source=%s, description=%s): %w", f.Source, f.Description, err))
-			break
-		}
-	}
-
-	if len(errs) != 0 {
-		return errors.Join(errs...)
+func (svc *DatastoresClient) DeleteFirewallRule(ctx context.Context, storeID string, firewall FirewallRule) error {
+	if firewall.OpensAllPorts() {
+		firewall.Ports = nil
 	}
 
-	return nil
-}
-
-func (svc *DatastoresClient) DeleteFirewallRule(ctx context.Context, storeID string, firewall FirewallRule) error {
 	_, err := svc.client.Do(ctx, http.MethodDelete, "/api/firewall/api/v1/firewall/"+storeID, firewall)
 	if errors.Is(err, ErrResourceNotFound) {
 		tflog.Warn(ctx, "deleting firewall rule: not found", map[string]any{
@@ -109,62 +154,163 @@ func (svc *DatastoresClient) DeleteFirewallRule(ctx context.Context, storeID str
 		})
 		return nil
 	} else if err != nil {
-		return fmt.Errorf("deleting rule (source=%s, description=%s): %w", firewall.Source, firewall.Description, err)
+		return err
 	}
 
 	return nil
 }
 
-func (svc *DatastoresClient) DeleteFirewallRules(ctx context.Context, storeID string, firewalls []FirewallRule) error {
-	limiter := make(chan bool, 10)
+// deleteFirewallRules deletes the rules concurrently, the returned errors are in the order of the rules, nil for deleted rules
+func (svc *DatastoresClient) deleteFirewallRules(ctx context.Context, storeID string, firewalls []FirewallRule) []error {
+	errs := make([]error, len(firewalls))
 
 	var eg errgroup.Group
 
-	for _, f := range firewalls {
-		limiter <- true
-		f := f
-		eg.Go(func() error {
-			defer func() { <-limiter }()
-			err := svc.DeleteFirewallRule(ctx, storeID, f)
-
-			if err != nil {
-				return fmt.Errorf("deleting rule (source=%s, description=%s): %w", f.Source, f.Description, err)
-			}
+	eg.SetLimit(10)
 
+	for i, f := range firewalls {
+		eg.Go(func() error {
+			// a failed delete does not cancel the others
+			errs[i] = svc.DeleteFirewallRule(ctx, storeID, f)
 			return nil
 		})
 	}
 
-	if err := eg.Wait(); err != nil {
-		return err
+	_ = eg.Wait()
+
+	return errs
+}
+
+// firewallReplacement is a rule whose description or ports changed, CCX has one rule per source
+type firewallReplacement struct {
+	old, new FirewallRule
+}
+
+// firewallReplacements moves the rules which are replaced by a rule with the same source out of create and del
+func firewallReplacements(create, del []FirewallRule) (newCreate, newDel []FirewallRule, replace []firewallReplacement) {
+	newDel = slices.Clone(del)
+
+	for _, c := range create {
+		i := slices.IndexFunc(newDel, func(d FirewallRule) bool {
+			return sameCIDR(c.Source, d.Source)
+		})
+
+		if i == -1 {
+			newCreate = append(newCreate, c)
+			continue
+		}
+
+		replace = append(replace, firewallReplacement{old: newDel[i], new: c})
+		newDel = slices.Delete(newDel, i, i+1)
 	}
 
-	return nil
+	return newCreate, newDel, replace
 }
 
+// SetFirewallRules changes the rules of the datastore to firewalls, so that the datastore is never left unreachable:
+// new rules are created first, rules are replaced one by one, and the deleted rule is created again when creating its replacement fails,
+// and rules are only deleted when all rules were created. When any change fails, a *FirewallRulesError reports the state of each rule.
 func (svc *DatastoresClient) SetFirewallRules(ctx context.Context, storeID string, firewalls []FirewallRule) error {
-	slices.SortStableFunc(firewalls, func(a, b FirewallRule) int {
-		return strings.Compare(a.Source, b.Source)
-	})
+	have, err := svc.GetFirewallRules(ctx, storeID)
+	if err != nil {
+		return fmt.Errorf("getting firewalls: %w", err)
+	}
 
+	return svc.changeFirewallRules(ctx, storeID, have, firewalls)
+}
+
+// SetIPSetFirewallRules changes the rules of the ip set on the datastore to firewalls, like SetFirewallRules,
+// but only the rules of the ip set are compared and changed, so that other rules, e.g. of other ip sets changed at the same time, are kept
+func (svc *DatastoresClient) SetIPSetFirewallRules(ctx context.Context, storeID, name string, firewalls []FirewallRule) error {
 	have, err := svc.GetFirewallRules(ctx, storeID)
 	if err != nil {
 		return fmt.Errorf("getting firewalls: %w", err)
 	}
 
-	create, del := firewallsDiff(have, firewalls)
+	have = slices.DeleteFunc(have, func(f FirewallRule) bool {
+		n, ok := f.IPSet()
+		return !ok || n != name
+	})
+
+	return svc.changeFirewallRules(ctx, storeID, have, firewalls)
+}
+
+// changeFirewallRules changes the rules have of the datastore to firewalls, as described by SetFirewallRules
+func (svc *DatastoresClient) changeFirewallRules(ctx context.Context, storeID string, have, firewalls []FirewallRule) error {
+	slices.SortStableFunc(firewalls, func(a, b FirewallRule) int {
+		return strings.Compare(a.Source, b.Source)
+	})
+
+	create, del, replace := firewallReplacements(firewallsDiff(have, firewalls))
 
-	if len(del) > 0 {
-		if err = svc.DeleteFirewallRules(ctx, storeID, del); err != nil {
-			return fmt.Errorf("deleting rules: %w", err)
+	var (
+		results []FirewallRuleResult
+		failed  bool
+	)
+
+	for _, f := range create {
+		if err := svc.CreateFirewallRule(ctx, storeID, f); err != nil {
+			results = append(results, FirewallRuleResult{Rule: f, State: FirewallRuleNotCreated, Err: err})
+			failed = true
+		} else {
+			results = append(results, FirewallRuleResult{Rule: f, State: FirewallRuleCreated})
 		}
 	}
 
-	if len(create) > 0 {
-		if err = svc.CreateFirewallRules(ctx, storeID, create); err != nil {
-			return fmt.Errorf("creating rules: %w", err)
+	for _, r := range replace {
+		results = append(results, svc.replaceFirewallRule(ctx, storeID, r)...)
+	}
+
+	failed = failed || slices.ContainsFunc(results, FirewallRuleResult.failed)
+
+	if failed {
+		for _, f := range del {
+			results = append(results, FirewallRuleResult{Rule: f, State: FirewallRuleKept})
+		}
+	} else {
+		for i, err := range svc.deleteFirewallRules(ctx, storeID, del) {
+			if err != nil {
+				results = append(results, FirewallRuleResult{Rule: del[i], State: FirewallRuleNotDeleted, Err: err})
+			} else {
+				results = append(results, FirewallRuleResult{Rule: del[i], State: FirewallRuleDeleted})
+			}
 		}
 	}
 
+	if slices.ContainsFunc(results, FirewallRuleResult.failed) {
+		return &FirewallRulesError{Results: results}
+	}
+
 	return nil
 }
+
+// replaceFirewallRule deletes the old rule before creating the new one, as both have the same source,
+// and creates the old rule again when creating the new one fails
+func (svc *DatastoresClient) replaceFirewallRule(ctx context.Context, storeID string, r firewallReplacement) []FirewallRuleResult {
+	if err := svc.DeleteFirewallRule(ctx, storeID, r.old); err != nil {
+		return []FirewallRuleResult{
+			{Rule: r.old, State: FirewallRuleNotDeleted, Err: err},
+			{Rule: r.new, State: FirewallRuleNotCreated, Err: errors.New("the rule it replaces was not deleted")},
+		}
+	}
+
+	err := svc.CreateFirewallRule(ctx, storeID, r.new)
+	if err == nil {
+		return []FirewallRuleResult{
+			{Rule: r.old, State: FirewallRuleDeleted},
+			{Rule: r.new, State: FirewallRuleCreated},
+		}
+	}
+
+	tflog.Warn(ctx, "creating firewall rule failed, restoring the rule it replaces", map[string]any{
+		"source": r.new.Source, "err": err.Error(),
+	})
+
+	results := []FirewallRuleResult{{Rule: r.new, State: FirewallRuleNotCreated, Err: err}}
+
+	if err := svc.CreateFirewallRule(ctx, storeID, r.old); err != nil {
+		return append(results, FirewallRuleResult{Rule: r.old, State: FirewallRuleNotRestored, Err: err})
+	}
+
+	return append(results, FirewallRuleResult{Rule: r.old, State: FirewallRuleRestored})
+}
@@ -0,0 +1,292 @@
+package ccx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_firewallsDiff(t *testing.T) {
+	tests := []struct {
+		name       string
+		have       []FirewallRule
+		want       []FirewallRule
+		wantCreate []FirewallRule
+		wantDelete []FirewallRule
+	}{
+		{
+			name: "same",
+			have: []FirewallRule{{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"exporter", "postgres"}}},
+			want: []FirewallRule{{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"postgres", "exporter"}}},
+		},
+		{
+			name: "ports not set",
+			have: []FirewallRule{{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"exporter", "postgres"}}},
+			want: []FirewallRule{{Source: "1.2.3.4/32", Description: "foo"}},
+		},
+		{
+			name:       "ports changed",
+			have:       []FirewallRule{{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"exporter", "postgres"}}},
+			want:       []FirewallRule{{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"postgres"}}},
+			wantCreate: []FirewallRule{{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"postgres"}}},
+			wantDelete: []FirewallRule{{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"exporter", "postgres"}}},
+		},
+		{
+			name:       "all ports after narrowed ports",
+			have:       []FirewallRule{{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"postgres"}}},
+			want:       []FirewallRule{{Source: "1.2.3.4/32", Description: "foo", Ports: []string{AllPorts}}},
+			wantCreate: []FirewallRule{{Source: "1.2.3.4/32", Description: "foo", Ports: []string{AllPorts}}},
+			wantDelete: []FirewallRule{{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"postgres"}}},
+		},
+		{
+			name: "all ports",
+			have: []FirewallRule{{Source: "1.2.3.4/32", Description: "foo", Ports: []string{AllPorts}}},
+			want: []FirewallRule{{Source: "1.2.3.4/32", Description: "foo", Ports: []string{AllPorts}}},
+		},
+		{
+			name: "added and removed",
+			have: []FirewallRule{
+				{Source: "1.2.3.4/32", Description: "foo"},
+				{Source: "1.2.3.5/32", Description: "bar"},
+			},
+			want: []FirewallRule{
+				{Source: "1.2.3.4/32", Description: "foo"},
+				{Source: "1.2.3.6/32", Description: "baz", Ports: []string{"postgres"}},
+			},
+			wantCreate: []FirewallRule{{Source: "1.2.3.6/32", Description: "baz", Ports: []string{"postgres"}}},
+			wantDelete: []FirewallRule{{Source: "1.2.3.5/32", Description: "bar"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			create, del := firewallsDiff(tt.have, tt.want)
+
+			require.Equal(t, tt.wantCreate, create)
+			require.Equal(t, tt.wantDelete, del)
+		})
+	}
+}
+
+func TestDatastoresClient_SetFirewallRules(t *testing.T) {
+	httpcli := NewMockHTTPClient(t)
+
+	httpcli.EXPECT().Get(mock.Anything, "/api/firewall/api/v1/firewalls/datastore-id", mock.Anything).RunAndReturn(func(_ context.Context, _ string, target any) error {
+		return json.Unmarshal([]byte(`[
+			{"source": "1.2.3.4/32", "description": "foo", "ports": [{"port": "postgres", "port_no": 5432}, {"port": "exporter", "port_no": 9187}]},
+			{"source": "1.2.3.5/32", "description": "bar", "ports": [{"port": "postgres", "port_no": 5432}, {"port": "exporter", "port_no": 9187}]}
+		]`), target)
+	})
+
+	// ports of 1.2.3.5/32 are not set, so it is not changed
+	httpcli.EXPECT().Do(mock.Anything, http.MethodDelete, "/api/firewall/api/v1/firewall/datastore-id", FirewallRule{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"exporter", "postgres"}}).
+		Return(fakeHttpResponse(http.StatusOK, ""), nil).Once()
+	httpcli.EXPECT().Do(mock.Anything, http.MethodPost, "/api/firewall/api/v1/firewall/datastore-id", FirewallRule{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"postgres"}}).
+		Return(fakeHttpResponse(http.StatusOK, ""), nil).Once()
+
+	svc := &DatastoresClient{
+		client: httpcli,
+	}
+
+	err := svc.SetFirewallRules(context.Background(), "datastore-id", []FirewallRule{
+		{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"postgres"}},
+		{Source: "1.2.3.5/32", Description: "bar"},
+	})
+	require.NoError(t, err)
+}
+
+func TestDatastoresClient_SetFirewallRules_allPorts(t *testing.T) {
+	httpcli := NewMockHTTPClient(t)
+
+	httpcli.EXPECT().Get(mock.Anything, "/api/firewall/api/v1/firewalls/datastore-id", mock.Anything).RunAndReturn(func(_ context.Context, _ string, target any) error {
+		return json.Unmarshal([]byte(`[
+			{"source": "1.2.3.4/32", "description": "foo", "ports": [{"port": "postgres", "port_no": 5432}]}
+		]`), target)
+	})
+
+	// the rule opening all ports is created without ports
+	httpcli.EXPECT().Do(mock.Anything, http.MethodDelete, "/api/firewall/api/v1/firewall/datastore-id", FirewallRule{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"postgres"}}).
+		Return(fakeHttpResponse(http.StatusOK, ""), nil).Once()
+	httpcli.EXPECT().Do(mock.Anything, http.MethodPost, "/api/firewall/api/v1/firewall/datastore-id", FirewallRule{Source: "1.2.3.4/32", Description: "foo"}).
+		Return(fakeHttpResponse(http.StatusOK, ""), nil).Once()
+
+	svc := &DatastoresClient{
+		client: httpcli,
+	}
+
+	err := svc.SetFirewallRules(context.Background(), "datastore-id", []FirewallRule{
+		{Source: "1.2.3.4/32", Description: "foo", Ports: []string{AllPorts}},
+	})
+	require.NoError(t, err)
+}
+
+func TestDatastoresClient_SetIPSetFirewallRules(t *testing.T) {
+	httpcli := NewMockHTTPClient(t)
+
+	httpcli.EXPECT().Get(mock.Anything, "/api/firewall/api/v1/firewalls/datastore-id", mock.Anything).RunAndReturn(func(_ context.Context, _ string, target any) error {
+		return json.Unmarshal([]byte(`[
+			{"source": "1.2.3.4/32", "description": "ccx_ip_set:office", "ports": [{"port": "postgres", "port_no": 5432}]},
+			{"source": "1.2.3.5/32", "description": "ccx_ip_set:office", "ports": [{"port": "postgres", "port_no": 5432}]},
+			{"source": "1.2.3.6/32", "description": "ccx_ip_set:vpn", "ports": [{"port": "postgres", "port_no": 5432}]},
+			{"source": "1.2.3.7/32", "description": "app", "ports": [{"port": "postgres", "port_no": 5432}]}
+		]`), target)
+	})
+
+	// the rules of vpn and app are neither deleted nor replaced
+	httpcli.EXPECT().Do(mock.Anything, http.MethodPost, "/api/firewall/api/v1/firewall/datastore-id", FirewallRule{Source: "1.2.3.8/32", Description: "ccx_ip_set:office"}).
+		Return(fakeHttpResponse(http.StatusOK, ""), nil).Once()
+	httpcli.EXPECT().Do(mock.Anything, http.MethodDelete, "/api/firewall/api/v1/firewall/datastore-id", FirewallRule{Source: "1.2.3.5/32", Description: "ccx_ip_set:office", Ports: []string{"postgres"}}).
+		Return(fakeHttpResponse(http.StatusOK, ""), nil).Once()
+
+	svc := &DatastoresClient{
+		client: httpcli,
+	}
+
+	err := svc.SetIPSetFirewallRules(context.Background(), "datastore-id", "office", []FirewallRule{
+		{Source: "1.2.3.4/32", Description: "ccx_ip_set:office"},
+		{Source: "1.2.3.8/32", Description: "ccx_ip_set:office"},
+	})
+	require.NoError(t, err)
+}
+
+func TestDatastoresClient_SetFirewallRules_transactional(t *testing.T) {
+	have := `[
+		{"source": "1.2.3.4/32", "description": "office", "ports": [{"port": "postgres", "port_no": 5432}]},
+		{"source": "1.2.3.5/32", "description": "vpn", "ports": [{"port": "postgres", "port_no": 5432}]}
+	]`
+
+	// office is replaced, vpn is deleted and app is created
+	want := []FirewallRule{
+		{Source: "1.2.3.4/32", Description: "head office", Ports: []string{"postgres"}},
+		{Source: "1.2.3.6/32", Description: "app", Ports: []string{"postgres"}},
+	}
+
+	var (
+		office     = FirewallRule{Source: "1.2.3.4/32", Description: "office", Ports: []string{"postgres"}}
+		headOffice = want[0]
+		vpn        = FirewallRule{Source: "1.2.3.5/32", Description: "vpn", Ports: []string{"postgres"}}
+		app        = want[1]
+	)
+
+	apiErr := errors.New("api error")
+
+	tests := []struct {
+		name      string
+		fail      map[string]error // method and description of the calls which fail
+		wantCalls []string
+		wantErr   []FirewallRuleResult
+	}{
+		{
+			name: "create before delete",
+			wantCalls: []string{
+				"POST app",
+				"DELETE office", "POST head office",
+				"DELETE vpn",
+			},
+		},
+		{
+			name: "create fails, nothing is deleted",
+			fail: map[string]error{"POST app": apiErr},
+			wantCalls: []string{
+				"POST app",
+				"DELETE office", "POST head office",
+			},
+			wantErr: []FirewallRuleResult{
+				{Rule: app, State: FirewallRuleNotCreated, Err: apiErr},
+				{Rule: office, State: FirewallRuleDeleted},
+				{Rule: headOffice, State: FirewallRuleCreated},
+				{Rule: vpn, State: FirewallRuleKept},
+			},
+		},
+		{
+			name: "replacement fails, replaced rule is restored",
+			fail: map[string]error{"POST head office": apiErr},
+			wantCalls: []string{
+				"POST app",
+				"DELETE office", "POST head office", "POST office",
+			},
+			wantErr: []FirewallRuleResult{
+				{Rule: app, State: FirewallRuleCreated},
+				{Rule: headOffice, State: FirewallRuleNotCreated, Err: apiErr},
+				{Rule: office, State: FirewallRuleRestored},
+				{Rule: vpn, State: FirewallRuleKept},
+			},
+		},
+		{
+			name: "replacement and restore fail",
+			fail: map[string]error{"POST head office": apiErr, "POST office": apiErr},
+			wantCalls: []string{
+				"POST app",
+				"DELETE office", "POST head office", "POST office",
+			},
+			wantErr: []FirewallRuleResult{
+				{Rule: app, State: FirewallRuleCreated},
+				{Rule: headOffice, State: FirewallRuleNotCreated, Err: apiErr},
+				{Rule: office, State: FirewallRuleNotRestored, Err: apiErr},
+				{Rule: vpn, State: FirewallRuleKept},
+			},
+		},
+		{
+			name: "delete fails",
+			fail: map[string]error{"DELETE vpn": apiErr},
+			wantCalls: []string{
+				"POST app",
+				"DELETE office", "POST head office",
+				"DELETE vpn",
+			},
+			wantErr: []FirewallRuleResult{
+				{Rule: app, State: FirewallRuleCreated},
+				{Rule: office, State: FirewallRuleDeleted},
+				{Rule: headOffice, State: FirewallRuleCreated},
+				{Rule: vpn, State: FirewallRuleNotDeleted, Err: apiErr},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpcli := NewMockHTTPClient(t)
+
+			httpcli.EXPECT().Get(mock.Anything, "/api/firewall/api/v1/firewalls/datastore-id", mock.Anything).RunAndReturn(func(_ context.Context, _ string, target any) error {
+				return json.Unmarshal([]byte(have), target)
+			})
+
+			var calls []string
+
+			httpcli.EXPECT().Do(mock.Anything, mock.Anything, "/api/firewall/api/v1/firewall/datastore-id", mock.Anything).RunAndReturn(func(_ context.Context, method, _ string, body any) (*http.Response, error) {
+				call := method + " " + body.(FirewallRule).Description
+				calls = append(calls, call)
+
+				if err := tt.fail[call]; err != nil {
+					return nil, err
+				}
+
+				return fakeHttpResponse(http.StatusOK, ""), nil
+			})
+
+			svc := &DatastoresClient{
+				client: httpcli,
+			}
+
+			err := svc.SetFirewallRules(context.Background(), "datastore-id", slices.Clone(want))
+
+			require.Equal(t, tt.wantCalls, calls)
+
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var fwErr *FirewallRulesError
+			require.ErrorAs(t, err, &fwErr)
+			require.Equal(t, tt.wantErr, fwErr.Results)
+			require.ErrorIs(t, err, apiErr)
+		})
+	}
+}
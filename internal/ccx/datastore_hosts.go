@@ -5,22 +5,26 @@ import (
 	"time"
 )
 
+type getHostsResponseItem struct {
+	ID            string    `json:"host_uuid"`
+	CreatedAt     time.Time `json:"created_at"`
+	CloudProvider string    `json:"cloud_provider"`
+	Hostname      string    `json:"host_fqdn"`
+	AZ            string    `json:"host_az"`
+	InstanceType  string    `json:"instance_type"`
+	DiskType      string    `json:"disk_type"`
+	DiskSize      uint64    `json:"disk_size"`
+	Role          string    `json:"role"`
+	Status        string    `json:"status"`
+	Port          int       `json:"port"`
+	Region        struct {
+		Code string `json:"code"`
+	} `json:"region"`
+}
+
 type getHostsResponse struct {
 	UUID  string
-	Hosts []struct {
-		ID            string    `json:"host_uuid"`
-		CreatedAt     time.Time `json:"created_at"`
-		CloudProvider string    `json:"cloud_provider"`
-		AZ            string    `json:"host_az"`
-		InstanceType  string    `json:"instance_type"`
-		DiskType      string    `json:"disk_type"`
-		DiskSize      uint64    `json:"disk_size"`
-		Role          string    `json:"role"`
-		Port          int       `json:"port"`
-		Region        struct {
-			Code string `json:"code"`
-		} `json:"region"`
-	} `json:"database_nodes"`
+	Hosts []getHostsResponseItem `json:"database_nodes"`
 }
 
 func (svc *DatastoresClient) GetHosts(ctx context.Context, clusterID string) ([]Host, error) {
@@ -38,11 +42,13 @@ func (svc *DatastoresClient) GetHosts(ctx context.Context, clusterID string) ([]
 			ID:            rs.Hosts[i].ID,
 			CreatedAt:     rs.Hosts[i].CreatedAt,
 			CloudProvider: rs.Hosts[i].CloudProvider,
+			Hostname:      rs.Hosts[i].Hostname,
 			AZ:            rs.Hosts[i].AZ,
 			InstanceType:  rs.Hosts[i].InstanceType,
 			DiskType:      rs.Hosts[i].DiskType,
 			DiskSize:      rs.Hosts[i].DiskSize,
 			Role:          rs.Hosts[i].Role,
+			Status:        rs.Hosts[i].Status,
 			Region:        rs.Hosts[i].Region.Code,
 			Port:          rs.Hosts[i].Port,
 		}
@@ -0,0 +1,58 @@
+package ccx
+
+import (
+	"context"
+)
+
+type listDatastoresResponse []getDatastoreResponse
+
+// List returns all datastores visible to the account.
+// The returned datastores do not include firewall rules, hosts or DSNs, use Read for the full datastore.
+func (svc *DatastoresClient) List(ctx context.Context) ([]Datastore, error) {
+	rs, err := svc.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ls := make([]Datastore, 0, len(rs))
+	for i := range rs {
+		if datastoreGone(rs[i].Status) {
+			continue
+		}
+
+		ls = append(ls, datastoreFromResponse(rs[i]))
+	}
+
+	return ls, nil
+}
+
+// ListInVPC returns the datastores in the vpc, including those being deleted or failed to be deleted,
+// as they are attached to the vpc until the API no longer returns them or they are DELETED.
+func (svc *DatastoresClient) ListInVPC(ctx context.Context, vpcID string) ([]Datastore, error) {
+	rs, err := svc.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ls []Datastore
+
+	for i := range rs {
+		if rs[i].Status == "DELETED" || rs[i].Vpc == nil || rs[i].Vpc.VpcUUID != vpcID {
+			continue
+		}
+
+		ls = append(ls, datastoreFromResponse(rs[i]))
+	}
+
+	return ls, nil
+}
+
+func (svc *DatastoresClient) list(ctx context.Context) (listDatastoresResponse, error) {
+	var rs listDatastoresResponse
+
+	if err := svc.client.Get(ctx, "/api/deployment/v3/data-stores", &rs); err != nil {
+		return nil, err
+	}
+
+	return rs, nil
+}
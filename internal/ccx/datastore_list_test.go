@@ -0,0 +1,46 @@
+package ccx
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatastoresClient_ListInVPC(t *testing.T) {
+	httpcli := NewMockHTTPClient(t)
+
+	response := `[
+		{"uuid": "datastore-1", "cluster_name": "luna", "cluster_status": "DEPLOYED", "vpc": {"vpc_uuid": "vpc-1"}},
+		{"uuid": "datastore-2", "cluster_name": "sol", "cluster_status": "DEPLOYED", "vpc": {"vpc_uuid": "vpc-2"}},
+		{"uuid": "datastore-3", "cluster_name": "io", "cluster_status": "DEPLOYED"},
+		{"uuid": "datastore-4", "cluster_name": "europa", "cluster_status": "DELETING", "vpc": {"vpc_uuid": "vpc-1"}},
+		{"uuid": "datastore-5", "cluster_name": "titan", "cluster_status": "DELETE_FAILED", "vpc": {"vpc_uuid": "vpc-1"}},
+		{"uuid": "datastore-6", "cluster_name": "rhea", "cluster_status": "DELETED", "vpc": {"vpc_uuid": "vpc-1"}}
+	]`
+
+	httpcli.EXPECT().Get(mock.Anything, "/api/deployment/v3/data-stores", mock.Anything).RunAndReturn(func(_ context.Context, _ string, target any) error {
+		return json.Unmarshal([]byte(response), target)
+	})
+
+	svc := &DatastoresClient{
+		client: httpcli,
+	}
+
+	ls, err := svc.ListInVPC(context.Background(), "vpc-1")
+	require.NoError(t, err)
+
+	var ids []string
+	for _, c := range ls {
+		ids = append(ids, c.ID)
+	}
+
+	// datastores being deleted are still in the vpc
+	require.Equal(t, []string{"datastore-1", "datastore-4", "datastore-5"}, ids)
+
+	ls, err = svc.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, ls, 3)
+}
@@ -0,0 +1,27 @@
+package ccx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// RotatePassword generates a new password for the default database user and awaits until it is in effect
+// the new password and DSNs are available by reading the datastore afterwards
+func (svc *DatastoresClient) RotatePassword(ctx context.Context, storeID string) error {
+	prev, err := svc.lastJobID(ctx, storeID, RotatePasswordJob)
+	if err != nil {
+		return fmt.Errorf("rotating password: %w", err)
+	}
+
+	_, err = svc.client.Do(ctx, http.MethodPost, "/api/prov/api/v2/cluster/"+storeID+"/rotate-password", nil)
+	if err != nil {
+		return fmt.Errorf("rotating password: %w", err)
+	}
+
+	if err := svc.awaitJob(ctx, storeID, RotatePasswordJob, prev); err != nil {
+		return fmt.Errorf("rotating password: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package ccx
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatastoresClient_RotatePassword(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(h *MockHTTPClient, j *MockJobsService)
+		wantErr error
+	}{
+		{
+			name: "rotate",
+			mock: func(h *MockHTTPClient, j *MockJobsService) {
+				h.EXPECT().Do(mock.Anything, http.MethodPost, "/api/prov/api/v2/cluster/datastore-id/rotate-password", nil).
+					Return(fakeHttpResponse(http.StatusOK, ""), nil)
+
+				j.EXPECT().Get(mock.Anything, "datastore-id", RotatePasswordJob).Return(&Job{ID: "job-0", Type: RotatePasswordJob, Status: JobStatusFinished}, nil)
+				j.EXPECT().AwaitNew(mock.Anything, "datastore-id", RotatePasswordJob, "job-0").Return(JobStatusFinished, nil)
+			},
+		},
+		{
+			name: "job failed",
+			mock: func(h *MockHTTPClient, j *MockJobsService) {
+				h.EXPECT().Do(mock.Anything, http.MethodPost, "/api/prov/api/v2/cluster/datastore-id/rotate-password", nil).
+					Return(fakeHttpResponse(http.StatusOK, ""), nil)
+
+				j.EXPECT().Get(mock.Anything, "datastore-id", RotatePasswordJob).Return(&Job{ID: "job-0", Type: RotatePasswordJob, Status: JobStatusFinished}, nil)
+				j.EXPECT().AwaitNew(mock.Anything, "datastore-id", RotatePasswordJob, "job-0").Return(JobStatusErrored, nil)
+				j.EXPECT().GetNew(mock.Anything, "datastore-id", RotatePasswordJob, "job-0").Return(&Job{
+					Type:   RotatePasswordJob,
+					Status: JobStatusErrored,
+					Error:  "user not found",
+				}, nil)
+			},
+			wantErr: ErrJobFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpcli := NewMockHTTPClient(t)
+			jobsSvc := NewMockJobsService(t)
+
+			tt.mock(httpcli, jobsSvc)
+
+			svc := &DatastoresClient{
+				client: httpcli,
+				jobs:   jobsSvc,
+			}
+
+			err := svc.RotatePassword(context.Background(), "datastore-id")
+
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package ccx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+type promoteRequest struct {
+	HostID string `json:"new_primary_uuid"`
+}
+
+// CanPromote checks that the host can become the new primary, it must be a healthy replica
+func CanPromote(hosts []Host, hostID string) error {
+	i := slices.IndexFunc(hosts, func(h Host) bool {
+		return h.ID == hostID
+	})
+
+	if i == -1 {
+		return fmt.Errorf("%w: host %q is not part of the datastore", ErrPromoteNotAllowed, hostID)
+	}
+
+	h := hosts[i]
+
+	if h.IsPrimary() {
+		return fmt.Errorf("%w: host %q is already the primary", ErrPromoteNotAllowed, hostID)
+	} else if !h.IsReplica() {
+		return fmt.Errorf("%w: host %q is not a replica, role is %q", ErrPromoteNotAllowed, hostID, h.Role)
+	} else if !h.IsHealthy() {
+		return fmt.Errorf("%w: host %q is not healthy, status is %q", ErrPromoteNotAllowed, hostID, h.Status)
+	}
+
+	return nil
+}
+
+// PromoteReplica switches the primary over to the replica with the given host id
+// the hosts are re-read before promoting, so that a replica which became unhealthy is not promoted
+func (svc *DatastoresClient) PromoteReplica(ctx context.Context, storeID, hostID string) error {
+	hosts, err := svc.GetHosts(ctx, storeID)
+	if err != nil {
+		return fmt.Errorf("getting hosts: %w", err)
+	}
+
+	if err := CanPromote(hosts, hostID); err != nil {
+		return err
+	}
+
+	prev, err := svc.lastJobID(ctx, storeID, PromoteReplicaJob)
+	if err != nil {
+		return fmt.Errorf("promoting replica: %w", err)
+	}
+
+	pr := promoteRequest{
+		HostID: hostID,
+	}
+
+	_, err = svc.client.Do(ctx, http.MethodPost, "/api/prov/api/v2/cluster/"+storeID+"/promote-replica", pr)
+	if err != nil {
+		return fmt.Errorf("promoting replica: %w", err)
+	}
+
+	if err := svc.awaitJob(ctx, storeID, PromoteReplicaJob, prev); err != nil {
+		return fmt.Errorf("promoting replica: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package ccx
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testPromoteHosts = []Host{
+	{ID: "host-1", Role: "primary", Status: "ok"},
+	{ID: "host-2", Role: "replica", Status: "ok"},
+	{ID: "host-3", Role: "replica", Status: "failed"},
+	{ID: "host-4", Role: "unknown", Status: "ok"},
+}
+
+func TestCanPromote(t *testing.T) {
+	tests := []struct {
+		name    string
+		hostID  string
+		wantErr bool
+	}{
+		{name: "healthy replica", hostID: "host-2"},
+		{name: "already primary", hostID: "host-1", wantErr: true},
+		{name: "unhealthy replica", hostID: "host-3", wantErr: true},
+		{name: "not a replica", hostID: "host-4", wantErr: true},
+		{name: "unknown host", hostID: "host-5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CanPromote(testPromoteHosts, tt.hostID)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrPromoteNotAllowed)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDatastoresClient_PromoteReplica(t *testing.T) {
+	hosts := getHostsResponse{
+		UUID: "datastore-id",
+		Hosts: []getHostsResponseItem{
+			{ID: "host-1", Role: "primary", Status: "ok"},
+			{ID: "host-2", Role: "replica", Status: "ok"},
+			{ID: "host-3", Role: "replica", Status: "failed"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		hostID  string
+		mock    func(h *MockHTTPClient, j *MockJobsService)
+		wantErr error
+	}{
+		{
+			name:   "promote",
+			hostID: "host-2",
+			mock: func(h *MockHTTPClient, j *MockJobsService) {
+				h.EXPECT().Do(mock.Anything, http.MethodPost, "/api/prov/api/v2/cluster/datastore-id/promote-replica", promoteRequest{
+					HostID: "host-2",
+				}).Return(fakeHttpResponse(http.StatusOK, ""), nil)
+
+				j.EXPECT().Get(mock.Anything, "datastore-id", PromoteReplicaJob).Return(&Job{ID: "job-0", Type: PromoteReplicaJob, Status: JobStatusFinished}, nil)
+				j.EXPECT().AwaitNew(mock.Anything, "datastore-id", PromoteReplicaJob, "job-0").Return(JobStatusFinished, nil)
+			},
+		},
+		{
+			name:    "unhealthy replica is refused before calling the api",
+			hostID:  "host-3",
+			wantErr: ErrPromoteNotAllowed,
+		},
+		{
+			name:   "job failed",
+			hostID: "host-2",
+			mock: func(h *MockHTTPClient, j *MockJobsService) {
+				h.EXPECT().Do(mock.Anything, http.MethodPost, "/api/prov/api/v2/cluster/datastore-id/promote-replica", mock.Anything).
+					Return(fakeHttpResponse(http.StatusOK, ""), nil)
+
+				j.EXPECT().Get(mock.Anything, "datastore-id", PromoteReplicaJob).Return(&Job{ID: "job-0", Type: PromoteReplicaJob, Status: JobStatusFinished}, nil)
+				j.EXPECT().AwaitNew(mock.Anything, "datastore-id", PromoteReplicaJob, "job-0").Return(JobStatusErrored, nil)
+				j.EXPECT().GetNew(mock.Anything, "datastore-id", PromoteReplicaJob, "job-0").Return(&Job{Type: PromoteReplicaJob, Status: JobStatusErrored}, nil)
+			},
+			wantErr: ErrJobFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpcli := NewMockHTTPClient(t)
+			jobsSvc := NewMockJobsService(t)
+
+			MockHTTPClientExpectGet(httpcli, "/api/deployment/v2/data-stores/datastore-id/nodes", hosts, nil)
+
+			if tt.mock != nil {
+				tt.mock(httpcli, jobsSvc)
+			}
+
+			svc := &DatastoresClient{
+				client: httpcli,
+				jobs:   jobsSvc,
+			}
+
+			err := svc.PromoteReplica(context.Background(), "datastore-id", tt.hostID)
+
+			switch tt.wantErr {
+			case nil:
+				require.NoError(t, err)
+			case assert.AnError:
+				require.Error(t, err)
+			default:
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
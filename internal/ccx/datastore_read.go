@@ -17,6 +17,7 @@ type getDatastoreResponse struct {
 	CloudProvider string  `json:"cloud_provider"`
 	InstanceSize  string  `json:"instance_size"`
 	InstanceIOPS  *uint64 `json:"iops"`
+	Throughput    *uint64 `json:"throughput"`
 	DiskSize      *uint64 `json:"disk_size"`
 	DiskType      *string `json:"disk_type"`
 	DbVendor      string  `json:"database_vendor"`
@@ -46,6 +47,7 @@ type getDatastoreResponse struct {
 
 	MaintenanceSettings *MaintenanceSettings `json:"maintenance_settings"`
 	Notifications       Notifications        `json:"notifications"`
+	StorageAutoscale    *StorageAutoscale    `json:"storage_autoscale"`
 
 	PrimaryUrl string `json:"primary_url"`
 	ReplicaUrl string `json:"replica_url"`
@@ -90,14 +92,61 @@ func (svc *DatastoresClient) Read(ctx context.Context, id string) (*Datastore, e
 		return nil, err
 	}
 
-	switch rs.Status {
+	if datastoreGone(rs.Status) {
+		return nil, ErrResourceNotFound
+	}
+
+	c := datastoreFromResponse(rs)
+
+	if fw, err := svc.GetFirewallRules(ctx, id); err == nil {
+		c.FirewallRules = fw
+	} else if !errors.Is(err, ErrResourceNotFound) {
+		return nil, fmt.Errorf("getting firewall rules: %w", err)
+	}
+
+	if h, err := svc.GetHosts(ctx, id); err == nil {
+		c.Hosts = h
+	} else if !errors.Is(err, ErrResourceNotFound) {
+		return nil, fmt.Errorf("getting hosts: %w", err)
+	}
+
+	if c.SSLEnabled {
+		if ca, err := svc.GetCACertificate(ctx, id); err == nil {
+			c.CACertificate = ca
+		} else if !errors.Is(err, ErrResourceNotFound) {
+			return nil, fmt.Errorf("getting ca certificate: %w", err)
+		}
+	}
+
+	port, err := getPortFromDatastore(c)
+	if err != nil {
+		tflog.Warn(ctx, "failed to get port for store, reported dsn might be incorrect", map[string]any{
+			"id":  id,
+			"err": err.Error(),
+		})
+	}
+
+	c.PrimaryDsn = Dsn(rs.DbVendor, c.PrimaryUrl, port, rs.DbAccount.Username, rs.DbAccount.Password, rs.DbAccount.Database, c.SSLEnabled)
+	c.ReplicaDsn = Dsn(rs.DbVendor, c.ReplicaUrl, port, rs.DbAccount.Username, rs.DbAccount.Password, rs.DbAccount.Database, c.SSLEnabled)
+	c.Connection = connection(c, port)
+
+	return &c, nil
+}
+
+// datastoreGone reports whether a datastore with the given status should be treated as non-existent
+func datastoreGone(status string) bool {
+	switch status {
 	case "DEPLOY_FAILED",
 		"DELETING",
 		"DELETE_FAILED",
 		"DELETED":
-		return nil, ErrResourceNotFound
+		return true
 	}
 
+	return false
+}
+
+func datastoreFromResponse(rs getDatastoreResponse) Datastore {
 	c := Datastore{
 		ID:                  rs.ID,
 		Name:                rs.Name,
@@ -112,10 +161,13 @@ func (svc *DatastoresClient) Read(ctx context.Context, id string) (*Datastore, e
 		VolumeType:          StringVal(rs.DiskType),
 		VolumeSize:          Uint64Val(rs.DiskSize),
 		VolumeIOPS:          Uint64Val(rs.InstanceIOPS),
+		VolumeThroughput:    Uint64Val(rs.Throughput),
 		HAEnabled:           rs.HighAvailability,
+		SSLEnabled:          rs.SSLEnabled,
 		AvailabilityZones:   rs.AZS,
 		Notifications:       rs.Notifications,
 		MaintenanceSettings: rs.MaintenanceSettings,
+		StorageAutoscale:    rs.StorageAutoscale,
 		PrimaryUrl:          rs.PrimaryUrl,
 		ReplicaUrl:          rs.ReplicaUrl,
 		Username:            rs.DbAccount.Username,
@@ -128,34 +180,13 @@ func (svc *DatastoresClient) Read(ctx context.Context, id string) (*Datastore, e
 		c.VpcUUID = rs.Vpc.VpcUUID
 	}
 
-	if fw, err := svc.GetFirewallRules(ctx, id); err == nil {
-		c.FirewallRules = fw
-	} else if !errors.Is(err, ErrResourceNotFound) {
-		return nil, fmt.Errorf("getting firewall rules: %w", err)
-	}
-
-	if h, err := svc.GetHosts(ctx, id); err == nil {
-		c.Hosts = h
-	} else if !errors.Is(err, ErrResourceNotFound) {
-		return nil, fmt.Errorf("getting hosts: %w", err)
-	}
-
-	port, err := getPortFromDatastore(c)
-	if err != nil {
-		tflog.Warn(ctx, "failed to get port for store, reported dsn might be incorrect", map[string]any{
-			"id":  id,
-			"err": err.Error(),
-		})
-	}
-
-	c.PrimaryDsn = Dsn(rs.DbVendor, c.PrimaryUrl, port, rs.DbAccount.Username, rs.DbAccount.Password, rs.DbAccount.Database)
-	c.ReplicaDsn = Dsn(rs.DbVendor, c.ReplicaUrl, port, rs.DbAccount.Username, rs.DbAccount.Password, rs.DbAccount.Database)
-
-	return &c, nil
+	return c
 }
 
-func Dsn(vendor string, host string, port int, username, password, dbname string) string {
-	var service string
+// Dsn returns a DSN for the vendor, with parameters requiring TLS when ssl is enabled
+// the credentials are escaped for the format, i.e. percent-encoded in URLs and quoted in the ADO.NET connection string
+func Dsn(vendor string, host string, port int, username, password, dbname string, ssl bool) string {
+	var service, query string
 
 	if !strings.Contains(host, ":") {
 		host += ":" + strconv.Itoa(port)
@@ -166,13 +197,23 @@ func Dsn(vendor string, host string, port int, username, password, dbname string
 		return ""
 	case "mysql", "mariadb", "percona":
 		service = "mysql"
+		if ssl {
+			query = "?ssl-mode=REQUIRED"
+		}
 	case "postgres", "pgsql":
 		service = "postgres"
+		if ssl {
+			query = "?sslmode=require"
+		}
 	case "redis", "valkey":
-		service = "rediss"
+		service = "rediss" // always TLS
 	case "microsoft":
-		return `Data Source=` + host + `;User ID=` + username + `;Password=` + password + `;Database=` + dbname
+		if ssl {
+			query = `;Encrypt=True`
+		}
+
+		return `Data Source=` + host + `;User ID=` + adoValue(username) + `;Password=` + adoValue(password) + `;Database=` + adoValue(dbname) + query
 	}
 
-	return service + "://" + username + ":" + password + "@" + host + "/" + dbname
+	return service + "://" + userinfo(username, password) + "@" + host + "/" + dbname + query
 }
@@ -8,21 +8,23 @@ import (
 )
 
 type updateRequest struct {
-	NewName         string         `json:"cluster_name"`
-	NewVolumeSize   uint           `json:"new_volume_size"`
-	NewInstanceSize string         `json:"new_instance_size"`
-	Remove          *removeHosts   `json:"remove_nodes"`
-	Add             *addHosts      `json:"add_nodes"`
-	Notifications   *notifications `json:"notifications"`
-	Maintenance     *maintenance   `json:"maintenance_settings"`
-	NewVolumeType   *changeVolume  `json:"change_volume"`
-	Tags            []string       `json:"tags"`
+	NewName         string            `json:"cluster_name"`
+	NewVolumeSize   uint              `json:"new_volume_size"`
+	NewInstanceSize string            `json:"new_instance_size"`
+	Remove          *removeHosts      `json:"remove_nodes"`
+	Add             *addHosts         `json:"add_nodes"`
+	Notifications   *notifications    `json:"notifications"`
+	Maintenance     *maintenance      `json:"maintenance_settings"`
+	NewVolumeType   *changeVolume     `json:"change_volume"`
+	Tags            []string          `json:"tags"`
+	Autoscale       *storageAutoscale `json:"storage_autoscale"`
 }
 
 type changeVolume struct {
-	NewVolumeType string `json:"new_volume_type"`
-	NewVolumeIOPS uint   `json:"new_volume_iops"`
-	NewVolumeSize uint   `json:"new_volume_size"`
+	NewVolumeType       string `json:"new_volume_type"`
+	NewVolumeIOPS       uint   `json:"new_volume_iops"`
+	NewVolumeSize       uint   `json:"new_volume_size"`
+	NewVolumeThroughput uint   `json:"new_volume_throughput,omitempty"`
 }
 
 type removeHosts struct {
@@ -44,6 +46,12 @@ type maintenance struct {
 	EndHour   uint64 `json:"end_hour"`
 }
 
+type storageAutoscale struct {
+	Enabled      bool   `json:"enabled"`
+	MaxSize      uint64 `json:"max_size"`
+	ThresholdPct int    `json:"threshold_pct"`
+}
+
 type hostSpecs struct {
 	InstanceSize string `json:"instance_size"`
 	AZ           string `json:"availability_zone"`
@@ -54,7 +62,7 @@ func (svc *DatastoresClient) Update(ctx context.Context, old, next Datastore) (*
 
 	updated, err := svc.update(ctx, old, next)
 	if err != nil {
-		return nil, fmt.Errorf("updating datastore: %s", err)
+		return nil, fmt.Errorf("updating datastore: %w", err)
 	}
 
 	resized, err := svc.resize(ctx, old, next)
@@ -77,14 +85,85 @@ func (svc *DatastoresClient) update(ctx context.Context, old, next Datastore) (b
 		return false, nil
 	}
 
-	_, err := svc.client.Do(ctx, http.MethodPatch, "/api/prov/api/v2/cluster/"+next.ID, ur)
+	resizing := ur.NewInstanceSize != ""
+	modifyingVolume := ur.NewVolumeType != nil || ur.NewVolumeSize != 0
+
+	// the jobs of earlier changes are skipped when awaiting the jobs of this change
+	var (
+		prevInstanceSizeJob, prevModifyVolumeJob string
+		err                                      error
+	)
+
+	if resizing {
+		if prevInstanceSizeJob, err = svc.lastJobID(ctx, next.ID, InstanceSizeJob); err != nil {
+			return false, err
+		}
+	}
+
+	if modifyingVolume {
+		if prevModifyVolumeJob, err = svc.lastJobID(ctx, next.ID, ModifyVolumeJob); err != nil {
+			return false, err
+		}
+	}
+
+	_, err = svc.client.Do(ctx, http.MethodPatch, "/api/prov/api/v2/cluster/"+next.ID, ur)
 	if err != nil {
 		return false, fmt.Errorf("updating datastore: %w", err)
 	}
 
+	if resizing { // nodes are resized one by one
+		if err := svc.awaitJob(ctx, next.ID, InstanceSizeJob, prevInstanceSizeJob); err != nil {
+			return false, fmt.Errorf("changing instance size: %w", err)
+		}
+	}
+
+	if modifyingVolume {
+		step := "changing volume size"
+
+		if old.VolumeType != next.VolumeType {
+			step = "changing volume type"
+		} else if ur.NewVolumeType != nil {
+			step = "changing volume iops and throughput"
+		}
+
+		if err := svc.awaitJob(ctx, next.ID, ModifyVolumeJob, prevModifyVolumeJob); err != nil {
+			return false, fmt.Errorf("%s: %w", step, err)
+		}
+	}
+
 	return true, nil
 }
 
+// lastJobID returns the id of the latest job of the type, empty when there is none
+func (svc *DatastoresClient) lastJobID(ctx context.Context, storeID string, jt JobType) (string, error) {
+	j, err := svc.jobs.Get(ctx, storeID, jt)
+	if err != nil {
+		return "", fmt.Errorf("getting the last %s job: %w", jt, err)
+	}
+
+	return j.ID, nil
+}
+
+// awaitJob waits for the job to finish, skipping the job previousID of an earlier change
+// a failed job is reported with the errors of the job and of its nodes
+func (svc *DatastoresClient) awaitJob(ctx context.Context, storeID string, jt JobType, previousID string) error {
+	status, err := svc.jobs.AwaitNew(ctx, storeID, jt, previousID)
+	if err != nil {
+		return fmt.Errorf("awaiting job: %w", err)
+	} else if status == JobStatusFinished {
+		return nil
+	}
+
+	j, err := svc.jobs.GetNew(ctx, storeID, jt, previousID)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %s", ErrJobFailed, jt, status)
+	} else if err := j.Err(); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s: %s", ErrJobFailed, jt, status)
+}
+
 func (svc *DatastoresClient) resize(ctx context.Context, old, next Datastore) (bool, error) {
 	if old.Size == next.Size {
 		return false, nil
@@ -92,7 +171,7 @@ func (svc *DatastoresClient) resize(ctx context.Context, old, next Datastore) (b
 
 	adding := old.Size < next.Size
 
-	if adding {
+	if adding && len(next.AddNodes) == 0 {
 		have := len(next.AvailabilityZones)
 		need := int(next.Size - old.Size)
 		missing := need - have
@@ -118,11 +197,6 @@ func (svc *DatastoresClient) resize(ctx context.Context, old, next Datastore) (b
 		return false, fmt.Errorf("computing resize: %w", err)
 	}
 
-	_, err = svc.client.Do(ctx, http.MethodPatch, "/api/prov/api/v2/cluster/"+next.ID, ur)
-	if err != nil {
-		return false, err
-	}
-
 	var jt JobType
 	if adding {
 		jt = AddNodeJob
@@ -130,7 +204,17 @@ func (svc *DatastoresClient) resize(ctx context.Context, old, next Datastore) (b
 		jt = RemoveNodeJob
 	}
 
-	status, err := svc.jobs.Await(ctx, old.ID, jt)
+	prev, err := svc.lastJobID(ctx, old.ID, jt)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = svc.client.Do(ctx, http.MethodPatch, "/api/prov/api/v2/cluster/"+next.ID, ur)
+	if err != nil {
+		return false, err
+	}
+
+	status, err := svc.jobs.AwaitNew(ctx, old.ID, jt, prev)
 	if err != nil {
 		return false, fmt.Errorf("awaiting resize job: %w", err)
 	} else if status != JobStatusFinished {
@@ -143,7 +227,15 @@ func (svc *DatastoresClient) resize(ctx context.Context, old, next Datastore) (b
 func (svc *DatastoresClient) updateSizeRequest(ctx context.Context, old, next Datastore) (updateRequest, error) {
 	var ur updateRequest
 
-	if old.Size > next.Size { // remove the oldest non-primary nodes
+	if old.Size > next.Size && len(next.RemoveNodeIDs) != 0 { // remove the chosen nodes
+		ids, err := chosenRemovableNodeIds(old.Hosts, next.RemoveNodeIDs, int(old.Size-next.Size))
+		if err != nil {
+			return ur, err
+		}
+
+		ur.Remove = &removeHosts{HostIDs: ids}
+		return ur, nil
+	} else if old.Size > next.Size { // remove the oldest non-primary nodes
 		ids, err := oldestRemovableNodeIds(old.Hosts, int(old.Size-next.Size))
 		if err != nil {
 			return ur, err
@@ -153,6 +245,16 @@ func (svc *DatastoresClient) updateSizeRequest(ctx context.Context, old, next Da
 		return ur, nil
 	}
 
+	if len(next.AddNodes) != 0 { // add the chosen nodes
+		specs, err := chosenNodeSpecs(old.Hosts, next.AddNodes, int(next.Size-old.Size), next.InstanceSize)
+		if err != nil {
+			return ur, err
+		}
+
+		ur.Add = &addHosts{Specs: specs}
+		return ur, nil
+	}
+
 	have := len(next.AvailabilityZones)
 	need := int(next.Size - old.Size)
 	missing := need - have
@@ -221,7 +323,18 @@ func (svc *DatastoresClient) updateRequest(old, next Datastore) (updateRequest,
 		changedVolume = true
 		ok = true
 	}
+
+	// iops and throughput can be changed without changing the volume type
+	if old.VolumeIOPS != next.VolumeIOPS || old.VolumeThroughput != next.VolumeThroughput {
+		cv.NewVolumeType = next.VolumeType
+		changedVolume = true
+		ok = true
+	}
+
 	if changedVolume {
+		cv.NewVolumeIOPS = uint(next.VolumeIOPS)
+		cv.NewVolumeThroughput = uint(next.VolumeThroughput)
+
 		ur.NewVolumeType = &cv
 	}
 
@@ -234,6 +347,16 @@ func (svc *DatastoresClient) updateRequest(old, next Datastore) (updateRequest,
 		ok = true
 	}
 
+	if a := next.StorageAutoscale; a != nil && (old.StorageAutoscale == nil || *old.StorageAutoscale != *a) {
+		ur.Autoscale = &storageAutoscale{
+			Enabled:      a.Enabled,
+			MaxSize:      a.MaxSize,
+			ThresholdPct: a.ThresholdPct,
+		}
+
+		ok = true
+	}
+
 	if next.MaintenanceSettings != nil {
 		ur.Maintenance = &maintenance{
 			DayOfWeek: uint32(next.MaintenanceSettings.DayOfWeek),
@@ -268,6 +391,62 @@ func oldestRemovableNodeIds(hosts []Host, count int) ([]string, error) {
 	return ls, nil
 }
 
+// chosenRemovableNodeIds checks that the chosen nodes can be removed, the primary cannot be removed
+func chosenRemovableNodeIds(hosts []Host, ids []string, count int) ([]string, error) {
+	if len(ids) != count {
+		return nil, fmt.Errorf("cannot remove %d nodes, %d nodes chosen for removal", count, len(ids))
+	}
+
+	for i, id := range ids {
+		j := slices.IndexFunc(hosts, func(h Host) bool {
+			return h.ID == id
+		})
+
+		if j == -1 {
+			return nil, fmt.Errorf("cannot remove node %q, it is not part of the datastore", id)
+		} else if hosts[j].IsPrimary() {
+			return nil, fmt.Errorf("cannot remove node %q, it is the primary", id)
+		} else if slices.Contains(ids[:i], id) {
+			return nil, fmt.Errorf("cannot remove node %q, it is chosen more than once", id)
+		}
+	}
+
+	return ids, nil
+}
+
+// chosenNodeSpecs returns the specs of the chosen nodes, the instance size defaults to instanceSize, i.e. the one of the datastore,
+// and to the one of the newest node only when instanceSize is empty
+func chosenNodeSpecs(hosts []Host, nodes []NodeSpec, count int, instanceSize string) ([]hostSpecs, error) {
+	if len(nodes) != count {
+		return nil, fmt.Errorf("cannot add %d nodes, %d nodes chosen for adding", count, len(nodes))
+	}
+
+	ls := make([]hostSpecs, 0, count)
+
+	for _, n := range nodes {
+		if n.AZ == "" {
+			return nil, fmt.Errorf("availability zone is required for each node to add")
+		}
+
+		size := n.InstanceSize
+		if size == "" {
+			specs, err := newestNodeSpecs(hosts, 1, []string{n.AZ}, instanceSize)
+			if err != nil {
+				return nil, err
+			}
+
+			size = specs[0].InstanceSize
+		}
+
+		ls = append(ls, hostSpecs{
+			InstanceSize: size,
+			AZ:           n.AZ,
+		})
+	}
+
+	return ls, nil
+}
+
 func newestNodeSpecs(hosts []Host, count int, azs []string, instanceSize string) ([]hostSpecs, error) {
 	if len(hosts) == 0 {
 		return nil, fmt.Errorf("no nodes available")
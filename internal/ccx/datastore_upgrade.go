@@ -0,0 +1,63 @@
+package ccx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+type upgradeRequest struct {
+	NewDBVersion string `json:"new_db_version"`
+}
+
+// UpgradePathSupported checks if a datastore of the vendor can be upgraded in place from one version to another
+// versions are listed by CCX from oldest to newest, so only moving forward in that list is supported
+func UpgradePathSupported(vendors []DBVendorInfo, vendor, from, to string) bool {
+	i := slices.IndexFunc(vendors, func(v DBVendorInfo) bool {
+		return v.Code == vendor
+	})
+
+	if i == -1 {
+		return false
+	}
+
+	versions := vendors[i].Versions
+
+	a := slices.IndexFunc(versions, func(v string) bool { return strings.EqualFold(v, from) })
+	b := slices.IndexFunc(versions, func(v string) bool { return strings.EqualFold(v, to) })
+
+	return a != -1 && b != -1 && a < b
+}
+
+func (svc *DatastoresClient) UpgradeDBVersion(ctx context.Context, c Datastore, version string) error {
+	vendors, err := svc.contentSvc.DBVendors(ctx)
+	if err != nil {
+		return fmt.Errorf("loading db vendor information: %w", err)
+	}
+
+	if !UpgradePathSupported(vendors, c.DBVendor, c.DBVersion, version) {
+		return fmt.Errorf("%w: %s from %q to %q", ErrUpgradeNotSupported, c.DBVendor, c.DBVersion, version)
+	}
+
+	prev, err := svc.lastJobID(ctx, c.ID, UpgradeDBJob)
+	if err != nil {
+		return fmt.Errorf("upgrading database version: %w", err)
+	}
+
+	ur := upgradeRequest{
+		NewDBVersion: version,
+	}
+
+	_, err = svc.client.Do(ctx, http.MethodPost, "/api/prov/api/v2/cluster/"+c.ID+"/upgrade", ur)
+	if err != nil {
+		return fmt.Errorf("upgrading database version: %w", err)
+	}
+
+	if err := svc.awaitJob(ctx, c.ID, UpgradeDBJob, prev); err != nil {
+		return fmt.Errorf("upgrading database version: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package ccx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testVendors = []DBVendorInfo{
+	{Code: "postgres", Versions: []string{"14", "15", "16"}},
+	{Code: "percona", Versions: []string{"8"}},
+}
+
+func TestUpgradePathSupported(t *testing.T) {
+	tests := []struct {
+		name   string
+		vendor string
+		from   string
+		to     string
+		want   bool
+	}{
+		{name: "next version", vendor: "postgres", from: "15", to: "16", want: true},
+		{name: "skipping a version", vendor: "postgres", from: "14", to: "16", want: true},
+		{name: "downgrade", vendor: "postgres", from: "16", to: "15", want: false},
+		{name: "same version", vendor: "postgres", from: "16", to: "16", want: false},
+		{name: "unknown target version", vendor: "postgres", from: "16", to: "17", want: false},
+		{name: "unknown current version", vendor: "postgres", from: "13", to: "16", want: false},
+		{name: "unknown vendor", vendor: "mssql", from: "2019", to: "2022", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, UpgradePathSupported(testVendors, tt.vendor, tt.from, tt.to))
+		})
+	}
+}
+
+func TestDatastoresClient_UpgradeDBVersion(t *testing.T) {
+	store := Datastore{ID: "datastore-id", DBVendor: "postgres", DBVersion: "15"}
+
+	tests := []struct {
+		name    string
+		version string
+		mock    func(h *MockHTTPClient, j *MockJobsService)
+		wantErr error
+	}{
+		{
+			name:    "upgrade",
+			version: "16",
+			mock: func(h *MockHTTPClient, j *MockJobsService) {
+				h.EXPECT().Do(mock.Anything, http.MethodPost, "/api/prov/api/v2/cluster/datastore-id/upgrade", upgradeRequest{
+					NewDBVersion: "16",
+				}).Return(fakeHttpResponse(http.StatusOK, ""), nil)
+
+				j.EXPECT().Get(mock.Anything, "datastore-id", UpgradeDBJob).Return(&Job{ID: "job-0", Type: UpgradeDBJob, Status: JobStatusFinished}, nil)
+				j.EXPECT().AwaitNew(mock.Anything, "datastore-id", UpgradeDBJob, "job-0").Return(JobStatusFinished, nil)
+			},
+		},
+		{
+			name:    "downgrade is refused before calling the api",
+			version: "14",
+			wantErr: ErrUpgradeNotSupported,
+		},
+		{
+			name:    "job failed",
+			version: "16",
+			mock: func(h *MockHTTPClient, j *MockJobsService) {
+				h.EXPECT().Do(mock.Anything, http.MethodPost, "/api/prov/api/v2/cluster/datastore-id/upgrade", mock.Anything).
+					Return(fakeHttpResponse(http.StatusOK, ""), nil)
+
+				j.EXPECT().Get(mock.Anything, "datastore-id", UpgradeDBJob).Return(&Job{ID: "job-0", Type: UpgradeDBJob, Status: JobStatusFinished}, nil)
+				j.EXPECT().AwaitNew(mock.Anything, "datastore-id", UpgradeDBJob, "job-0").Return(JobStatusErrored, nil)
+				j.EXPECT().GetNew(mock.Anything, "datastore-id", UpgradeDBJob, "job-0").Return(&Job{Type: UpgradeDBJob, Status: JobStatusErrored}, nil)
+			},
+			wantErr: ErrJobFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpcli := NewMockHTTPClient(t)
+			jobsSvc := NewMockJobsService(t)
+			contentSvc := NewMockContentService(t)
+
+			contentSvc.EXPECT().DBVendors(mock.Anything).Return(testVendors, nil)
+
+			if tt.mock != nil {
+				tt.mock(httpcli, jobsSvc)
+			}
+
+			svc := &DatastoresClient{
+				client:     httpcli,
+				jobs:       jobsSvc,
+				contentSvc: contentSvc,
+			}
+
+			err := svc.UpgradeDBVersion(context.Background(), store, tt.version)
+
+			switch tt.wantErr {
+			case nil:
+				require.NoError(t, err)
+			case assert.AnError:
+				require.Error(t, err)
+			default:
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDatastoresClient_UpgradeDBVersion_earlierJob(t *testing.T) {
+	httpcli := NewMockHTTPClient(t)
+	contentSvc := NewMockContentService(t)
+
+	contentSvc.EXPECT().DBVendors(mock.Anything).Return(testVendors, nil)
+
+	httpcli.EXPECT().Do(mock.Anything, http.MethodPost, "/api/prov/api/v2/cluster/datastore-id/upgrade", mock.Anything).
+		Return(fakeHttpResponse(http.StatusOK, ""), nil).Once()
+
+	// the finished upgrade job of an earlier change is listed first until the new job is started
+	earlier := jobsResponseJobItem{JobID: "job-1", Type: UpgradeDBJob, Status: JobStatusFinished}
+	responses := []jobsResponse{
+		{Jobs: []jobsResponseJobItem{earlier}},
+		{Jobs: []jobsResponseJobItem{earlier}},
+		{Jobs: []jobsResponseJobItem{{JobID: "job-2", Type: UpgradeDBJob, Status: JobStatusRunning}, earlier}},
+		{Jobs: []jobsResponseJobItem{{JobID: "job-2", Type: UpgradeDBJob, Status: JobStatusFinished}, earlier}},
+	}
+
+	reads := 0
+
+	httpcli.EXPECT().Get(mock.Anything, "/api/deployment/v2/data-stores/datastore-id/jobs?limit=10&offset=0", mock.Anything).RunAndReturn(func(_ context.Context, _ string, target any) error {
+		rs := responses[min(reads, len(responses)-1)]
+		reads++
+
+		b, err := json.Marshal(rs)
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(b, target)
+	})
+
+	svc := &DatastoresClient{
+		client: httpcli,
+		jobs: &JobsClient{
+			httpcli: httpcli,
+			tick:    time.Millisecond,
+			timeout: time.Second,
+		},
+		contentSvc: contentSvc,
+	}
+
+	err := svc.UpgradeDBVersion(context.Background(), Datastore{ID: "datastore-id", DBVendor: "postgres", DBVersion: "15"}, "16")
+	require.NoError(t, err)
+	require.Equal(t, len(responses), reads)
+}
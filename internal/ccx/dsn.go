@@ -0,0 +1,252 @@
+package ccx
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DsnInfo is a DSN split into its parts
+type DsnInfo struct {
+	Vendor   string
+	Host     string
+	Port     int
+	Username string
+	Password string
+	DbName   string
+	SSL      bool
+}
+
+// ParseDsn is the inverse of Dsn: it splits a DSN in any format returned by Dsn into its parts.
+// Vendors sharing a format are not told apart, e.g. mariadb and percona DSNs are parsed as mysql.
+// Credentials are unescaped, as Dsn escapes them.
+func ParseDsn(dsn string) (DsnInfo, error) {
+	dsn = strings.TrimSpace(dsn)
+
+	if dsn == "" {
+		return DsnInfo{}, errors.New("empty dsn")
+	}
+
+	if scheme, rest, ok := strings.Cut(dsn, "://"); ok {
+		return parseURIDsn(scheme, rest)
+	}
+
+	return parseADODsn(dsn)
+}
+
+func parseURIDsn(scheme, rest string) (DsnInfo, error) {
+	var info DsnInfo
+
+	switch strings.ToLower(scheme) {
+	case "mysql":
+		info.Vendor = "mysql"
+	case "postgres", "postgresql":
+		info.Vendor = "postgres"
+	case "rediss":
+		info.Vendor = "redis"
+		info.SSL = true // always TLS
+	case "redis":
+		info.Vendor = "redis"
+	default:
+		return DsnInfo{}, fmt.Errorf("unsupported dsn scheme %q", scheme)
+	}
+
+	// credentials which are not escaped may contain "@", the host never does
+	if at := strings.LastIndex(rest, "@"); at != -1 {
+		username, password, _ := strings.Cut(rest[:at], ":")
+
+		info.Username = unescapeUserinfo(username)
+		info.Password = unescapeUserinfo(password)
+		rest = rest[at+1:]
+	}
+
+	hostport, path, _ := strings.Cut(rest, "/")
+	path, query, _ := strings.Cut(path, "?")
+
+	host, port, err := splitHostPort(hostport, ":")
+	if err != nil {
+		return DsnInfo{}, err
+	}
+
+	info.Host = host
+	info.Port = port
+	info.DbName = path
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return DsnInfo{}, fmt.Errorf("parsing dsn query: %w", err)
+	}
+
+	switch {
+	case strings.EqualFold(values.Get("ssl-mode"), "REQUIRED"),
+		strings.EqualFold(values.Get("sslmode"), "require"):
+		info.SSL = true
+	}
+
+	return info, nil
+}
+
+// parseADODsn parses the ADO.NET connection string of Microsoft SQL Server
+func parseADODsn(dsn string) (DsnInfo, error) {
+	info := DsnInfo{
+		Vendor: "microsoft",
+	}
+
+	pairs, err := adoPairs(dsn)
+	if err != nil {
+		return DsnInfo{}, err
+	}
+
+	var found bool
+
+	for _, kv := range pairs {
+		k, v := kv[0], kv[1]
+
+		switch strings.ToLower(k) {
+		case "data source", "server", "address":
+			// the port is separated by a comma in SQL Server, but Dsn separates it by a colon
+			sep := ":"
+			if strings.Contains(v, ",") {
+				sep = ","
+			}
+
+			host, port, err := splitHostPort(strings.TrimPrefix(v, "tcp:"), sep)
+			if err != nil {
+				return DsnInfo{}, err
+			}
+
+			info.Host = host
+			info.Port = port
+			found = true
+		case "user id", "uid", "user":
+			info.Username = v
+		case "password", "pwd":
+			info.Password = v
+		case "database", "initial catalog":
+			info.DbName = v
+		case "encrypt":
+			switch strings.ToLower(v) {
+			case "true", "yes", "mandatory", "strict":
+				info.SSL = true
+			}
+		}
+	}
+
+	if !found {
+		return DsnInfo{}, errors.New("invalid dsn: no host found")
+	}
+
+	return info, nil
+}
+
+// unescapeUserinfo decodes the percent-encoded credentials of a URL, credentials which are not valid escapes are kept as they are
+func unescapeUserinfo(s string) string {
+	if u, err := url.PathUnescape(s); err == nil {
+		return u
+	}
+
+	return s
+}
+
+// adoPairs splits an ADO.NET connection string into key and value pairs,
+// a value may be quoted with " or ', e.g. when it contains a ";", a quote inside it is doubled
+func adoPairs(dsn string) ([][2]string, error) {
+	var pairs [][2]string
+
+	for s := dsn; ; {
+		s = strings.TrimLeft(s, "; ")
+		if s == "" {
+			return pairs, nil
+		}
+
+		eq := strings.IndexByte(s, '=')
+		if semi := strings.IndexByte(s, ';'); eq == -1 || (semi != -1 && semi < eq) {
+			kv, _, _ := strings.Cut(s, ";")
+			return nil, fmt.Errorf("invalid dsn: %q is not a key=value pair", kv)
+		}
+
+		key := strings.TrimSpace(s[:eq])
+		s = strings.TrimLeft(s[eq+1:], " ")
+
+		var value string
+
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			v, rest, err := adoQuotedValue(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid dsn: value of %q: %w", key, err)
+			}
+
+			value, s = v, strings.TrimLeft(rest, " ")
+
+			if s != "" && s[0] != ';' {
+				return nil, fmt.Errorf("invalid dsn: value of %q: unexpected %q after the quoted value", key, s)
+			}
+		} else {
+			v, rest, _ := strings.Cut(s, ";")
+			value, s = strings.TrimSpace(v), rest
+		}
+
+		pairs = append(pairs, [2]string{key, value})
+	}
+}
+
+// adoQuotedValue returns the quoted value at the start of s, and the rest of s after the closing quote
+func adoQuotedValue(s string) (string, string, error) {
+	q := s[0]
+
+	var b strings.Builder
+
+	for i := 1; i < len(s); i++ {
+		if s[i] != q {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		if i+1 < len(s) && s[i+1] == q { // doubled quote
+			b.WriteByte(q)
+			i++
+
+			continue
+		}
+
+		return b.String(), s[i+1:], nil
+	}
+
+	return "", "", errors.New("missing closing quote")
+}
+
+// splitHostPort splits host and an optional port, which may be separated by sep, ipv6 hosts must be in brackets
+func splitHostPort(hostport, sep string) (string, int, error) {
+	if hostport == "" {
+		return "", 0, errors.New("invalid dsn: no host found")
+	}
+
+	i := strings.LastIndex(hostport, sep)
+	if i == -1 || strings.HasSuffix(hostport, "]") {
+		return strings.Trim(hostport, "[]"), 0, nil
+	}
+
+	if sep == ":" {
+		host, p, err := net.SplitHostPort(hostport)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid dsn host: %w", err)
+		}
+
+		port, err := strconv.Atoi(p)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid dsn port %q: %w", p, err)
+		}
+
+		return host, port, nil
+	}
+
+	port, err := strconv.Atoi(strings.TrimSpace(hostport[i+1:]))
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid dsn port %q: %w", hostport[i+1:], err)
+	}
+
+	return strings.Trim(hostport[:i], "[] "), port, nil
+}
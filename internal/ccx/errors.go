@@ -42,6 +42,15 @@ var (
 	// ErrApplyParameterGroup indicates failure to apply a parameter group
 	ErrApplyParameterGroup = errors.New("failed to apply a parameter group")
 
+	// ErrUpgradeNotSupported indicates that the database cannot be upgraded in place to the requested version
+	ErrUpgradeNotSupported = errors.New("database version upgrade is not supported")
+
+	// ErrPromoteNotAllowed indicates that the host cannot be promoted to primary, e.g. it is not a healthy replica
+	ErrPromoteNotAllowed = errors.New("promoting host to primary is not allowed")
+
+	// ErrJobFailed indicates that a job finished with an error
+	ErrJobFailed = errors.New("job failed")
+
 	// ErrMaintenanceSettings indicates failure to configure maintenance settings
 	ErrMaintenanceSettings = errors.New("failed to configure maintenance settings")
 )
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 func NewJobsClient(httpcli HTTPClient, timeout time.Duration) JobsService {
@@ -27,18 +29,34 @@ type jobsResponse struct {
 }
 
 type jobsResponseJobItem struct {
-	JobID  string    `json:"job_id"`
-	Type   JobType   `json:"type"`
+	JobID  string                 `json:"job_id"`
+	Type   JobType                `json:"type"`
+	Status JobStatus              `json:"status"`
+	Error  string                 `json:"error"`
+	Nodes  []jobsResponseNodeItem `json:"nodes"`
+}
+
+type jobsResponseNodeItem struct {
+	HostID string    `json:"host_uuid"`
 	Status JobStatus `json:"status"`
+	Error  string    `json:"error"`
 }
 
+// Await waits for the latest job of the type to finish or fail
 func (svc *JobsClient) Await(ctx context.Context, storeID string, job JobType) (JobStatus, error) {
+	return svc.AwaitNew(ctx, storeID, job, "")
+}
+
+// AwaitNew waits for a job of the type to finish or fail, skipping the job previousID,
+// i.e. the latest job of the type before the request which started the awaited job, so that a job of an earlier change does not satisfy the wait
+func (svc *JobsClient) AwaitNew(ctx context.Context, storeID string, job JobType, previousID string) (JobStatus, error) {
 	timeout := time.Now().Add(svc.timeout)
 	ticker := time.NewTicker(svc.tick)
 
 	var (
-		status JobStatus
-		err    error
+		status   JobStatus
+		err      error
+		progress = make(map[string]JobStatus) // host id -> last logged status
 	)
 
 	for time.Now().Before(timeout) {
@@ -51,12 +69,18 @@ func (svc *JobsClient) Await(ctx context.Context, storeID string, job JobType) (
 		default:
 		}
 
-		status, err = svc.GetStatus(ctx, storeID, job)
+		var j *Job
+
+		j, err = svc.GetNew(ctx, storeID, job, previousID)
 
 		if err != nil {
 			return JobStatusUnknown, fmt.Errorf("getting job status: %w", err)
 		}
 
+		status = j.Status
+
+		logNodeProgress(ctx, storeID, *j, progress)
+
 		switch status {
 		case JobStatusFinished, JobStatusErrored:
 			return status, nil
@@ -72,17 +96,82 @@ func (svc *JobsClient) Await(ctx context.Context, storeID string, job JobType) (
 	return JobStatusUnknown, fmt.Errorf("job did not finish in %s", svc.timeout)
 }
 
+// logNodeProgress logs the status of each node of the job, when it has changed since it was last logged
+func logNodeProgress(ctx context.Context, storeID string, j Job, progress map[string]JobStatus) {
+	for _, n := range j.Nodes {
+		if progress[n.HostID] == n.Status {
+			continue
+		}
+
+		progress[n.HostID] = n.Status
+
+		fields := map[string]any{
+			"id":     storeID,
+			"job":    string(j.Type),
+			"host":   n.HostID,
+			"status": string(n.Status),
+		}
+
+		if n.Error != "" {
+			fields["error"] = n.Error
+		}
+
+		tflog.Info(ctx, "job progress", fields)
+	}
+}
+
 func (svc *JobsClient) GetStatus(ctx context.Context, storeID string, job JobType) (JobStatus, error) {
+	j, err := svc.Get(ctx, storeID, job)
+	if err != nil {
+		return JobStatusUnknown, err
+	}
+
+	return j.Status, nil
+}
+
+// Get returns the latest job of the type, with the status of each node
+// a job which has not been found yet has JobStatusUnknown
+func (svc *JobsClient) Get(ctx context.Context, storeID string, job JobType) (*Job, error) {
+	return svc.GetNew(ctx, storeID, job, "")
+}
+
+// GetNew returns the latest job of the type, like Get, unless it is the job previousID, then the new job has not been found yet and has JobStatusUnknown
+func (svc *JobsClient) GetNew(ctx context.Context, storeID string, job JobType, previousID string) (*Job, error) {
 	var rs jobsResponse
 	if err := svc.httpcli.Get(ctx, "/api/deployment/v2/data-stores/"+storeID+"/jobs?limit=10&offset=0", &rs); err != nil {
-		return JobStatusUnknown, fmt.Errorf("getting job status: %w", err)
+		return nil, fmt.Errorf("getting job status: %w", err)
 	}
 
 	for i := range rs.Jobs {
-		if rs.Jobs[i].Type == job {
-			return rs.Jobs[i].Status, nil
+		if rs.Jobs[i].Type != job {
+			continue
 		}
+
+		if previousID != "" && rs.Jobs[i].JobID == previousID {
+			break
+		}
+
+		return jobFromResponseItem(rs.Jobs[i]), nil
+	}
+
+	return &Job{Type: job, Status: JobStatusUnknown}, nil
+}
+
+func jobFromResponseItem(item jobsResponseJobItem) *Job {
+	j := Job{
+		ID:     item.JobID,
+		Type:   item.Type,
+		Status: item.Status,
+		Error:  item.Error,
+	}
+
+	for _, n := range item.Nodes {
+		j.Nodes = append(j.Nodes, JobNode{
+			HostID: n.HostID,
+			Status: n.Status,
+			Error:  n.Error,
+		})
 	}
 
-	return JobStatusUnknown, nil
+	return &j
 }
@@ -309,3 +309,117 @@ func Test_jobs_Await(t *testing.T) {
 		})
 	}
 }
+
+func Test_jobs_AwaitNew(t *testing.T) {
+	stale := jobsResponseJobItem{JobID: "456", Type: InstanceSizeJob, Status: JobStatusFinished}
+
+	tests := []struct {
+		name      string
+		responses []jobsResponse
+		want      JobStatus
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name: "the finished job of an earlier change is skipped",
+			responses: []jobsResponse{
+				{Jobs: []jobsResponseJobItem{stale}},
+				{Jobs: []jobsResponseJobItem{{JobID: "789", Type: InstanceSizeJob, Status: JobStatusRunning}, stale}},
+				{Jobs: []jobsResponseJobItem{{JobID: "789", Type: InstanceSizeJob, Status: JobStatusFinished}, stale}},
+			},
+			want:      JobStatusFinished,
+			wantCalls: 3,
+		},
+		{
+			name: "the new job is not started",
+			responses: []jobsResponse{
+				{Jobs: []jobsResponseJobItem{stale}},
+			},
+			want:    JobStatusUnknown,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := 0
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/api/deployment/v2/data-stores/123/jobs", r.URL.Path)
+
+				rs := tt.responses[min(i, len(tt.responses)-1)]
+				i++
+
+				if err := json.NewEncoder(w).Encode(rs); err != nil {
+					panic(err)
+				}
+			}))
+
+			defer srv.Close()
+
+			svc := JobsClient{
+				httpcli: NewTestHTTPClient(srv.URL),
+				tick:    time.Millisecond * 10,
+				timeout: time.Millisecond * 100,
+			}
+
+			got, err := svc.AwaitNew(context.Background(), "123", InstanceSizeJob, "456")
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantCalls, i)
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_jobs_Get(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/deployment/v2/data-stores/123/jobs", r.URL.Path)
+
+		err := json.NewEncoder(w).Encode(jobsResponse{
+			Jobs: []jobsResponseJobItem{
+				{
+					JobID:  "456",
+					Type:   InstanceSizeJob,
+					Status: JobStatusRunning,
+					Nodes: []jobsResponseNodeItem{
+						{HostID: "host-1", Status: JobStatusFinished},
+						{HostID: "host-2", Status: JobStatusRunning},
+					},
+				},
+			},
+			Total: 1,
+		})
+
+		if err != nil {
+			panic(err)
+		}
+	}))
+
+	defer srv.Close()
+
+	svc := JobsClient{
+		httpcli: NewTestHTTPClient(srv.URL),
+	}
+
+	got, err := svc.Get(context.Background(), "123", InstanceSizeJob)
+	require.NoError(t, err)
+
+	require.Equal(t, &Job{
+		ID:     "456",
+		Type:   InstanceSizeJob,
+		Status: JobStatusRunning,
+		Nodes: []JobNode{
+			{HostID: "host-1", Status: JobStatusFinished},
+			{HostID: "host-2", Status: JobStatusRunning},
+		},
+	}, got)
+
+	got, err = svc.Get(context.Background(), "123", DeployStoreJob)
+	require.NoError(t, err)
+	require.Equal(t, JobStatusUnknown, got.Status)
+}
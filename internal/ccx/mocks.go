@@ -339,6 +339,69 @@ func (_c *MockDatastoresService_Create_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// CreateFirewallRule provides a mock function for the type MockDatastoresService
+func (_mock *MockDatastoresService) CreateFirewallRule(ctx context.Context, storeID string, firewall FirewallRule) error {
+	ret := _mock.Called(ctx, storeID, firewall)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFirewallRule")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, FirewallRule) error); ok {
+		r0 = returnFunc(ctx, storeID, firewall)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDatastoresService_CreateFirewallRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFirewallRule'
+type MockDatastoresService_CreateFirewallRule_Call struct {
+	*mock.Call
+}
+
+// CreateFirewallRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storeID string
+//   - firewall FirewallRule
+func (_e *MockDatastoresService_Expecter) CreateFirewallRule(ctx interface{}, storeID interface{}, firewall interface{}) *MockDatastoresService_CreateFirewallRule_Call {
+	return &MockDatastoresService_CreateFirewallRule_Call{Call: _e.mock.On("CreateFirewallRule", ctx, storeID, firewall)}
+}
+
+func (_c *MockDatastoresService_CreateFirewallRule_Call) Run(run func(ctx context.Context, storeID string, firewall FirewallRule)) *MockDatastoresService_CreateFirewallRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 FirewallRule
+		if args[2] != nil {
+			arg2 = args[2].(FirewallRule)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDatastoresService_CreateFirewallRule_Call) Return(err error) *MockDatastoresService_CreateFirewallRule_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDatastoresService_CreateFirewallRule_Call) RunAndReturn(run func(ctx context.Context, storeID string, firewall FirewallRule) error) *MockDatastoresService_CreateFirewallRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function for the type MockDatastoresService
 func (_mock *MockDatastoresService) Delete(ctx context.Context, id string) error {
 	ret := _mock.Called(ctx, id)
@@ -396,47 +459,110 @@ func (_c *MockDatastoresService_Delete_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
-// Read provides a mock function for the type MockDatastoresService
-func (_mock *MockDatastoresService) Read(ctx context.Context, id string) (*Datastore, error) {
-	ret := _mock.Called(ctx, id)
+// DeleteFirewallRule provides a mock function for the type MockDatastoresService
+func (_mock *MockDatastoresService) DeleteFirewallRule(ctx context.Context, storeID string, firewall FirewallRule) error {
+	ret := _mock.Called(ctx, storeID, firewall)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Read")
+		panic("no return value specified for DeleteFirewallRule")
 	}
 
-	var r0 *Datastore
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, FirewallRule) error); ok {
+		r0 = returnFunc(ctx, storeID, firewall)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDatastoresService_DeleteFirewallRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFirewallRule'
+type MockDatastoresService_DeleteFirewallRule_Call struct {
+	*mock.Call
+}
+
+// DeleteFirewallRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storeID string
+//   - firewall FirewallRule
+func (_e *MockDatastoresService_Expecter) DeleteFirewallRule(ctx interface{}, storeID interface{}, firewall interface{}) *MockDatastoresService_DeleteFirewallRule_Call {
+	return &MockDatastoresService_DeleteFirewallRule_Call{Call: _e.mock.On("DeleteFirewallRule", ctx, storeID, firewall)}
+}
+
+func (_c *MockDatastoresService_DeleteFirewallRule_Call) Run(run func(ctx context.Context, storeID string, firewall FirewallRule)) *MockDatastoresService_DeleteFirewallRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 FirewallRule
+		if args[2] != nil {
+			arg2 = args[2].(FirewallRule)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDatastoresService_DeleteFirewallRule_Call) Return(err error) *MockDatastoresService_DeleteFirewallRule_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDatastoresService_DeleteFirewallRule_Call) RunAndReturn(run func(ctx context.Context, storeID string, firewall FirewallRule) error) *MockDatastoresService_DeleteFirewallRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFirewallRules provides a mock function for the type MockDatastoresService
+func (_mock *MockDatastoresService) GetFirewallRules(ctx context.Context, storeID string) ([]FirewallRule, error) {
+	ret := _mock.Called(ctx, storeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFirewallRules")
+	}
+
+	var r0 []FirewallRule
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*Datastore, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]FirewallRule, error)); ok {
+		return returnFunc(ctx, storeID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *Datastore); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []FirewallRule); ok {
+		r0 = returnFunc(ctx, storeID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*Datastore)
+			r0 = ret.Get(0).([]FirewallRule)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+		r1 = returnFunc(ctx, storeID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDatastoresService_Read_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Read'
-type MockDatastoresService_Read_Call struct {
+// MockDatastoresService_GetFirewallRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFirewallRules'
+type MockDatastoresService_GetFirewallRules_Call struct {
 	*mock.Call
 }
 
-// Read is a helper method to define mock.On call
+// GetFirewallRules is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockDatastoresService_Expecter) Read(ctx interface{}, id interface{}) *MockDatastoresService_Read_Call {
-	return &MockDatastoresService_Read_Call{Call: _e.mock.On("Read", ctx, id)}
+//   - storeID string
+func (_e *MockDatastoresService_Expecter) GetFirewallRules(ctx interface{}, storeID interface{}) *MockDatastoresService_GetFirewallRules_Call {
+	return &MockDatastoresService_GetFirewallRules_Call{Call: _e.mock.On("GetFirewallRules", ctx, storeID)}
 }
 
-func (_c *MockDatastoresService_Read_Call) Run(run func(ctx context.Context, id string)) *MockDatastoresService_Read_Call {
+func (_c *MockDatastoresService_GetFirewallRules_Call) Run(run func(ctx context.Context, storeID string)) *MockDatastoresService_GetFirewallRules_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -454,47 +580,119 @@ func (_c *MockDatastoresService_Read_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockDatastoresService_Read_Call) Return(datastore *Datastore, err error) *MockDatastoresService_Read_Call {
-	_c.Call.Return(datastore, err)
+func (_c *MockDatastoresService_GetFirewallRules_Call) Return(firewallRules []FirewallRule, err error) *MockDatastoresService_GetFirewallRules_Call {
+	_c.Call.Return(firewallRules, err)
 	return _c
 }
 
-func (_c *MockDatastoresService_Read_Call) RunAndReturn(run func(ctx context.Context, id string) (*Datastore, error)) *MockDatastoresService_Read_Call {
+func (_c *MockDatastoresService_GetFirewallRules_Call) RunAndReturn(run func(ctx context.Context, storeID string) ([]FirewallRule, error)) *MockDatastoresService_GetFirewallRules_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetFirewallRules provides a mock function for the type MockDatastoresService
-func (_mock *MockDatastoresService) SetFirewallRules(ctx context.Context, storeID string, firewalls []FirewallRule) error {
-	ret := _mock.Called(ctx, storeID, firewalls)
+// List provides a mock function for the type MockDatastoresService
+func (_mock *MockDatastoresService) List(ctx context.Context) ([]Datastore, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetFirewallRules")
+		panic("no return value specified for List")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []FirewallRule) error); ok {
-		r0 = returnFunc(ctx, storeID, firewalls)
+	var r0 []Datastore
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]Datastore, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []Datastore); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Datastore)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDatastoresService_SetFirewallRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFirewallRules'
-type MockDatastoresService_SetFirewallRules_Call struct {
+// MockDatastoresService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockDatastoresService_List_Call struct {
 	*mock.Call
 }
 
-// SetFirewallRules is a helper method to define mock.On call
+// List is a helper method to define mock.On call
 //   - ctx context.Context
-//   - storeID string
-//   - firewalls []FirewallRule
-func (_e *MockDatastoresService_Expecter) SetFirewallRules(ctx interface{}, storeID interface{}, firewalls interface{}) *MockDatastoresService_SetFirewallRules_Call {
-	return &MockDatastoresService_SetFirewallRules_Call{Call: _e.mock.On("SetFirewallRules", ctx, storeID, firewalls)}
+func (_e *MockDatastoresService_Expecter) List(ctx interface{}) *MockDatastoresService_List_Call {
+	return &MockDatastoresService_List_Call{Call: _e.mock.On("List", ctx)}
 }
 
-func (_c *MockDatastoresService_SetFirewallRules_Call) Run(run func(ctx context.Context, storeID string, firewalls []FirewallRule)) *MockDatastoresService_SetFirewallRules_Call {
+func (_c *MockDatastoresService_List_Call) Run(run func(ctx context.Context)) *MockDatastoresService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDatastoresService_List_Call) Return(datastores []Datastore, err error) *MockDatastoresService_List_Call {
+	_c.Call.Return(datastores, err)
+	return _c
+}
+
+func (_c *MockDatastoresService_List_Call) RunAndReturn(run func(ctx context.Context) ([]Datastore, error)) *MockDatastoresService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListInVPC provides a mock function for the type MockDatastoresService
+func (_mock *MockDatastoresService) ListInVPC(ctx context.Context, vpcID string) ([]Datastore, error) {
+	ret := _mock.Called(ctx, vpcID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInVPC")
+	}
+
+	var r0 []Datastore
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]Datastore, error)); ok {
+		return returnFunc(ctx, vpcID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []Datastore); ok {
+		r0 = returnFunc(ctx, vpcID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Datastore)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, vpcID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDatastoresService_ListInVPC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInVPC'
+type MockDatastoresService_ListInVPC_Call struct {
+	*mock.Call
+}
+
+// ListInVPC is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vpcID string
+func (_e *MockDatastoresService_Expecter) ListInVPC(ctx interface{}, vpcID interface{}) *MockDatastoresService_ListInVPC_Call {
+	return &MockDatastoresService_ListInVPC_Call{Call: _e.mock.On("ListInVPC", ctx, vpcID)}
+}
+
+func (_c *MockDatastoresService_ListInVPC_Call) Run(run func(ctx context.Context, vpcID string)) *MockDatastoresService_ListInVPC_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -504,60 +702,55 @@ func (_c *MockDatastoresService_SetFirewallRules_Call) Run(run func(ctx context.
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 []FirewallRule
-		if args[2] != nil {
-			arg2 = args[2].([]FirewallRule)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDatastoresService_SetFirewallRules_Call) Return(err error) *MockDatastoresService_SetFirewallRules_Call {
-	_c.Call.Return(err)
+func (_c *MockDatastoresService_ListInVPC_Call) Return(datastores []Datastore, err error) *MockDatastoresService_ListInVPC_Call {
+	_c.Call.Return(datastores, err)
 	return _c
 }
 
-func (_c *MockDatastoresService_SetFirewallRules_Call) RunAndReturn(run func(ctx context.Context, storeID string, firewalls []FirewallRule) error) *MockDatastoresService_SetFirewallRules_Call {
+func (_c *MockDatastoresService_ListInVPC_Call) RunAndReturn(run func(ctx context.Context, vpcID string) ([]Datastore, error)) *MockDatastoresService_ListInVPC_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetMaintenanceSettings provides a mock function for the type MockDatastoresService
-func (_mock *MockDatastoresService) SetMaintenanceSettings(ctx context.Context, storeID string, settings MaintenanceSettings) error {
-	ret := _mock.Called(ctx, storeID, settings)
+// PromoteReplica provides a mock function for the type MockDatastoresService
+func (_mock *MockDatastoresService) PromoteReplica(ctx context.Context, storeID string, hostID string) error {
+	ret := _mock.Called(ctx, storeID, hostID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetMaintenanceSettings")
+		panic("no return value specified for PromoteReplica")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, MaintenanceSettings) error); ok {
-		r0 = returnFunc(ctx, storeID, settings)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, storeID, hostID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockDatastoresService_SetMaintenanceSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMaintenanceSettings'
-type MockDatastoresService_SetMaintenanceSettings_Call struct {
+// MockDatastoresService_PromoteReplica_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PromoteReplica'
+type MockDatastoresService_PromoteReplica_Call struct {
 	*mock.Call
 }
 
-// SetMaintenanceSettings is a helper method to define mock.On call
+// PromoteReplica is a helper method to define mock.On call
 //   - ctx context.Context
 //   - storeID string
-//   - settings MaintenanceSettings
-func (_e *MockDatastoresService_Expecter) SetMaintenanceSettings(ctx interface{}, storeID interface{}, settings interface{}) *MockDatastoresService_SetMaintenanceSettings_Call {
-	return &MockDatastoresService_SetMaintenanceSettings_Call{Call: _e.mock.On("SetMaintenanceSettings", ctx, storeID, settings)}
+//   - hostID string
+func (_e *MockDatastoresService_Expecter) PromoteReplica(ctx interface{}, storeID interface{}, hostID interface{}) *MockDatastoresService_PromoteReplica_Call {
+	return &MockDatastoresService_PromoteReplica_Call{Call: _e.mock.On("PromoteReplica", ctx, storeID, hostID)}
 }
 
-func (_c *MockDatastoresService_SetMaintenanceSettings_Call) Run(run func(ctx context.Context, storeID string, settings MaintenanceSettings)) *MockDatastoresService_SetMaintenanceSettings_Call {
+func (_c *MockDatastoresService_PromoteReplica_Call) Run(run func(ctx context.Context, storeID string, hostID string)) *MockDatastoresService_PromoteReplica_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -567,9 +760,9 @@ func (_c *MockDatastoresService_SetMaintenanceSettings_Call) Run(run func(ctx co
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 MaintenanceSettings
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(MaintenanceSettings)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -580,97 +773,830 @@ func (_c *MockDatastoresService_SetMaintenanceSettings_Call) Run(run func(ctx co
 	return _c
 }
 
-func (_c *MockDatastoresService_SetMaintenanceSettings_Call) Return(err error) *MockDatastoresService_SetMaintenanceSettings_Call {
+func (_c *MockDatastoresService_PromoteReplica_Call) Return(err error) *MockDatastoresService_PromoteReplica_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDatastoresService_SetMaintenanceSettings_Call) RunAndReturn(run func(ctx context.Context, storeID string, settings MaintenanceSettings) error) *MockDatastoresService_SetMaintenanceSettings_Call {
+func (_c *MockDatastoresService_PromoteReplica_Call) RunAndReturn(run func(ctx context.Context, storeID string, hostID string) error) *MockDatastoresService_PromoteReplica_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Read provides a mock function for the type MockDatastoresService
+func (_mock *MockDatastoresService) Read(ctx context.Context, id string) (*Datastore, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Read")
+	}
+
+	var r0 *Datastore
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*Datastore, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *Datastore); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Datastore)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDatastoresService_Read_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Read'
+type MockDatastoresService_Read_Call struct {
+	*mock.Call
+}
+
+// Read is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDatastoresService_Expecter) Read(ctx interface{}, id interface{}) *MockDatastoresService_Read_Call {
+	return &MockDatastoresService_Read_Call{Call: _e.mock.On("Read", ctx, id)}
+}
+
+func (_c *MockDatastoresService_Read_Call) Run(run func(ctx context.Context, id string)) *MockDatastoresService_Read_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDatastoresService_Read_Call) Return(datastore *Datastore, err error) *MockDatastoresService_Read_Call {
+	_c.Call.Return(datastore, err)
+	return _c
+}
+
+func (_c *MockDatastoresService_Read_Call) RunAndReturn(run func(ctx context.Context, id string) (*Datastore, error)) *MockDatastoresService_Read_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotatePassword provides a mock function for the type MockDatastoresService
+func (_mock *MockDatastoresService) RotatePassword(ctx context.Context, storeID string) error {
+	ret := _mock.Called(ctx, storeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotatePassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, storeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDatastoresService_RotatePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotatePassword'
+type MockDatastoresService_RotatePassword_Call struct {
+	*mock.Call
+}
+
+// RotatePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storeID string
+func (_e *MockDatastoresService_Expecter) RotatePassword(ctx interface{}, storeID interface{}) *MockDatastoresService_RotatePassword_Call {
+	return &MockDatastoresService_RotatePassword_Call{Call: _e.mock.On("RotatePassword", ctx, storeID)}
+}
+
+func (_c *MockDatastoresService_RotatePassword_Call) Run(run func(ctx context.Context, storeID string)) *MockDatastoresService_RotatePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDatastoresService_RotatePassword_Call) Return(err error) *MockDatastoresService_RotatePassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDatastoresService_RotatePassword_Call) RunAndReturn(run func(ctx context.Context, storeID string) error) *MockDatastoresService_RotatePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetFirewallRules provides a mock function for the type MockDatastoresService
+func (_mock *MockDatastoresService) SetFirewallRules(ctx context.Context, storeID string, firewalls []FirewallRule) error {
+	ret := _mock.Called(ctx, storeID, firewalls)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFirewallRules")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []FirewallRule) error); ok {
+		r0 = returnFunc(ctx, storeID, firewalls)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDatastoresService_SetFirewallRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFirewallRules'
+type MockDatastoresService_SetFirewallRules_Call struct {
+	*mock.Call
+}
+
+// SetFirewallRules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storeID string
+//   - firewalls []FirewallRule
+func (_e *MockDatastoresService_Expecter) SetFirewallRules(ctx interface{}, storeID interface{}, firewalls interface{}) *MockDatastoresService_SetFirewallRules_Call {
+	return &MockDatastoresService_SetFirewallRules_Call{Call: _e.mock.On("SetFirewallRules", ctx, storeID, firewalls)}
+}
+
+func (_c *MockDatastoresService_SetFirewallRules_Call) Run(run func(ctx context.Context, storeID string, firewalls []FirewallRule)) *MockDatastoresService_SetFirewallRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []FirewallRule
+		if args[2] != nil {
+			arg2 = args[2].([]FirewallRule)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDatastoresService_SetFirewallRules_Call) Return(err error) *MockDatastoresService_SetFirewallRules_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDatastoresService_SetFirewallRules_Call) RunAndReturn(run func(ctx context.Context, storeID string, firewalls []FirewallRule) error) *MockDatastoresService_SetFirewallRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetIPSetFirewallRules provides a mock function for the type MockDatastoresService
+func (_mock *MockDatastoresService) SetIPSetFirewallRules(ctx context.Context, storeID string, name string, firewalls []FirewallRule) error {
+	ret := _mock.Called(ctx, storeID, name, firewalls)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIPSetFirewallRules")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []FirewallRule) error); ok {
+		r0 = returnFunc(ctx, storeID, name, firewalls)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDatastoresService_SetIPSetFirewallRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetIPSetFirewallRules'
+type MockDatastoresService_SetIPSetFirewallRules_Call struct {
+	*mock.Call
+}
+
+// SetIPSetFirewallRules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storeID string
+//   - name string
+//   - firewalls []FirewallRule
+func (_e *MockDatastoresService_Expecter) SetIPSetFirewallRules(ctx interface{}, storeID interface{}, name interface{}, firewalls interface{}) *MockDatastoresService_SetIPSetFirewallRules_Call {
+	return &MockDatastoresService_SetIPSetFirewallRules_Call{Call: _e.mock.On("SetIPSetFirewallRules", ctx, storeID, name, firewalls)}
+}
+
+func (_c *MockDatastoresService_SetIPSetFirewallRules_Call) Run(run func(ctx context.Context, storeID string, name string, firewalls []FirewallRule)) *MockDatastoresService_SetIPSetFirewallRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []FirewallRule
+		if args[3] != nil {
+			arg3 = args[3].([]FirewallRule)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDatastoresService_SetIPSetFirewallRules_Call) Return(err error) *MockDatastoresService_SetIPSetFirewallRules_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDatastoresService_SetIPSetFirewallRules_Call) RunAndReturn(run func(ctx context.Context, storeID string, name string, firewalls []FirewallRule) error) *MockDatastoresService_SetIPSetFirewallRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMaintenanceSettings provides a mock function for the type MockDatastoresService
+func (_mock *MockDatastoresService) SetMaintenanceSettings(ctx context.Context, storeID string, settings MaintenanceSettings) error {
+	ret := _mock.Called(ctx, storeID, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMaintenanceSettings")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, MaintenanceSettings) error); ok {
+		r0 = returnFunc(ctx, storeID, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDatastoresService_SetMaintenanceSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMaintenanceSettings'
+type MockDatastoresService_SetMaintenanceSettings_Call struct {
+	*mock.Call
+}
+
+// SetMaintenanceSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storeID string
+//   - settings MaintenanceSettings
+func (_e *MockDatastoresService_Expecter) SetMaintenanceSettings(ctx interface{}, storeID interface{}, settings interface{}) *MockDatastoresService_SetMaintenanceSettings_Call {
+	return &MockDatastoresService_SetMaintenanceSettings_Call{Call: _e.mock.On("SetMaintenanceSettings", ctx, storeID, settings)}
+}
+
+func (_c *MockDatastoresService_SetMaintenanceSettings_Call) Run(run func(ctx context.Context, storeID string, settings MaintenanceSettings)) *MockDatastoresService_SetMaintenanceSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 MaintenanceSettings
+		if args[2] != nil {
+			arg2 = args[2].(MaintenanceSettings)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDatastoresService_SetMaintenanceSettings_Call) Return(err error) *MockDatastoresService_SetMaintenanceSettings_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDatastoresService_SetMaintenanceSettings_Call) RunAndReturn(run func(ctx context.Context, storeID string, settings MaintenanceSettings) error) *MockDatastoresService_SetMaintenanceSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockDatastoresService
+func (_mock *MockDatastoresService) Update(ctx context.Context, old Datastore, next Datastore) (*Datastore, error) {
+	ret := _mock.Called(ctx, old, next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *Datastore
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Datastore, Datastore) (*Datastore, error)); ok {
+		return returnFunc(ctx, old, next)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Datastore, Datastore) *Datastore); ok {
+		r0 = returnFunc(ctx, old, next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Datastore)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, Datastore, Datastore) error); ok {
+		r1 = returnFunc(ctx, old, next)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDatastoresService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockDatastoresService_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - old Datastore
+//   - next Datastore
+func (_e *MockDatastoresService_Expecter) Update(ctx interface{}, old interface{}, next interface{}) *MockDatastoresService_Update_Call {
+	return &MockDatastoresService_Update_Call{Call: _e.mock.On("Update", ctx, old, next)}
+}
+
+func (_c *MockDatastoresService_Update_Call) Run(run func(ctx context.Context, old Datastore, next Datastore)) *MockDatastoresService_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 Datastore
+		if args[1] != nil {
+			arg1 = args[1].(Datastore)
+		}
+		var arg2 Datastore
+		if args[2] != nil {
+			arg2 = args[2].(Datastore)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDatastoresService_Update_Call) Return(datastore *Datastore, err error) *MockDatastoresService_Update_Call {
+	_c.Call.Return(datastore, err)
+	return _c
+}
+
+func (_c *MockDatastoresService_Update_Call) RunAndReturn(run func(ctx context.Context, old Datastore, next Datastore) (*Datastore, error)) *MockDatastoresService_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpgradeDBVersion provides a mock function for the type MockDatastoresService
+func (_mock *MockDatastoresService) UpgradeDBVersion(ctx context.Context, c Datastore, version string) error {
+	ret := _mock.Called(ctx, c, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpgradeDBVersion")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Datastore, string) error); ok {
+		r0 = returnFunc(ctx, c, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDatastoresService_UpgradeDBVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpgradeDBVersion'
+type MockDatastoresService_UpgradeDBVersion_Call struct {
+	*mock.Call
+}
+
+// UpgradeDBVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c Datastore
+//   - version string
+func (_e *MockDatastoresService_Expecter) UpgradeDBVersion(ctx interface{}, c interface{}, version interface{}) *MockDatastoresService_UpgradeDBVersion_Call {
+	return &MockDatastoresService_UpgradeDBVersion_Call{Call: _e.mock.On("UpgradeDBVersion", ctx, c, version)}
+}
+
+func (_c *MockDatastoresService_UpgradeDBVersion_Call) Run(run func(ctx context.Context, c Datastore, version string)) *MockDatastoresService_UpgradeDBVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 Datastore
+		if args[1] != nil {
+			arg1 = args[1].(Datastore)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDatastoresService_UpgradeDBVersion_Call) Return(err error) *MockDatastoresService_UpgradeDBVersion_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDatastoresService_UpgradeDBVersion_Call) RunAndReturn(run func(ctx context.Context, c Datastore, version string) error) *MockDatastoresService_UpgradeDBVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockVPCsService creates a new instance of MockVPCsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockVPCsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockVPCsService {
+	mock := &MockVPCsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockVPCsService is an autogenerated mock type for the VPCsService type
+type MockVPCsService struct {
+	mock.Mock
+}
+
+type MockVPCsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockVPCsService) EXPECT() *MockVPCsService_Expecter {
+	return &MockVPCsService_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockVPCsService
+func (_mock *MockVPCsService) Create(ctx context.Context, vpc VPC) (*VPC, error) {
+	ret := _mock.Called(ctx, vpc)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *VPC
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, VPC) (*VPC, error)); ok {
+		return returnFunc(ctx, vpc)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, VPC) *VPC); ok {
+		r0 = returnFunc(ctx, vpc)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*VPC)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, VPC) error); ok {
+		r1 = returnFunc(ctx, vpc)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockVPCsService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockVPCsService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vpc VPC
+func (_e *MockVPCsService_Expecter) Create(ctx interface{}, vpc interface{}) *MockVPCsService_Create_Call {
+	return &MockVPCsService_Create_Call{Call: _e.mock.On("Create", ctx, vpc)}
+}
+
+func (_c *MockVPCsService_Create_Call) Run(run func(ctx context.Context, vpc VPC)) *MockVPCsService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 VPC
+		if args[1] != nil {
+			arg1 = args[1].(VPC)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockVPCsService_Create_Call) Return(vPC *VPC, err error) *MockVPCsService_Create_Call {
+	_c.Call.Return(vPC, err)
+	return _c
+}
+
+func (_c *MockVPCsService_Create_Call) RunAndReturn(run func(ctx context.Context, vpc VPC) (*VPC, error)) *MockVPCsService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockVPCsService
+func (_mock *MockVPCsService) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockVPCsService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockVPCsService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockVPCsService_Expecter) Delete(ctx interface{}, id interface{}) *MockVPCsService_Delete_Call {
+	return &MockVPCsService_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockVPCsService_Delete_Call) Run(run func(ctx context.Context, id string)) *MockVPCsService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockVPCsService_Delete_Call) Return(err error) *MockVPCsService_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockVPCsService_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockVPCsService_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockVPCsService
+func (_mock *MockVPCsService) List(ctx context.Context) ([]VPC, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []VPC
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]VPC, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []VPC); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]VPC)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockVPCsService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockVPCsService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockVPCsService_Expecter) List(ctx interface{}) *MockVPCsService_List_Call {
+	return &MockVPCsService_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockVPCsService_List_Call) Run(run func(ctx context.Context)) *MockVPCsService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockVPCsService_List_Call) Return(vPCs []VPC, err error) *MockVPCsService_List_Call {
+	_c.Call.Return(vPCs, err)
+	return _c
+}
+
+func (_c *MockVPCsService_List_Call) RunAndReturn(run func(ctx context.Context) ([]VPC, error)) *MockVPCsService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Read provides a mock function for the type MockVPCsService
+func (_mock *MockVPCsService) Read(ctx context.Context, id string) (*VPC, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Read")
+	}
+
+	var r0 *VPC
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*VPC, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *VPC); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*VPC)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockVPCsService_Read_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Read'
+type MockVPCsService_Read_Call struct {
+	*mock.Call
+}
+
+// Read is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockVPCsService_Expecter) Read(ctx interface{}, id interface{}) *MockVPCsService_Read_Call {
+	return &MockVPCsService_Read_Call{Call: _e.mock.On("Read", ctx, id)}
+}
+
+func (_c *MockVPCsService_Read_Call) Run(run func(ctx context.Context, id string)) *MockVPCsService_Read_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockVPCsService_Read_Call) Return(vPC *VPC, err error) *MockVPCsService_Read_Call {
+	_c.Call.Return(vPC, err)
+	return _c
+}
+
+func (_c *MockVPCsService_Read_Call) RunAndReturn(run func(ctx context.Context, id string) (*VPC, error)) *MockVPCsService_Read_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function for the type MockDatastoresService
-func (_mock *MockDatastoresService) Update(ctx context.Context, old Datastore, next Datastore) (*Datastore, error) {
-	ret := _mock.Called(ctx, old, next)
+// Update provides a mock function for the type MockVPCsService
+func (_mock *MockVPCsService) Update(ctx context.Context, vpc VPC) (*VPC, error) {
+	ret := _mock.Called(ctx, vpc)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
-	var r0 *Datastore
+	var r0 *VPC
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, Datastore, Datastore) (*Datastore, error)); ok {
-		return returnFunc(ctx, old, next)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, VPC) (*VPC, error)); ok {
+		return returnFunc(ctx, vpc)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, Datastore, Datastore) *Datastore); ok {
-		r0 = returnFunc(ctx, old, next)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, VPC) *VPC); ok {
+		r0 = returnFunc(ctx, vpc)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*Datastore)
+			r0 = ret.Get(0).(*VPC)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, Datastore, Datastore) error); ok {
-		r1 = returnFunc(ctx, old, next)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, VPC) error); ok {
+		r1 = returnFunc(ctx, vpc)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDatastoresService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockDatastoresService_Update_Call struct {
+// MockVPCsService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockVPCsService_Update_Call struct {
 	*mock.Call
 }
 
 // Update is a helper method to define mock.On call
 //   - ctx context.Context
-//   - old Datastore
-//   - next Datastore
-func (_e *MockDatastoresService_Expecter) Update(ctx interface{}, old interface{}, next interface{}) *MockDatastoresService_Update_Call {
-	return &MockDatastoresService_Update_Call{Call: _e.mock.On("Update", ctx, old, next)}
+//   - vpc VPC
+func (_e *MockVPCsService_Expecter) Update(ctx interface{}, vpc interface{}) *MockVPCsService_Update_Call {
+	return &MockVPCsService_Update_Call{Call: _e.mock.On("Update", ctx, vpc)}
 }
 
-func (_c *MockDatastoresService_Update_Call) Run(run func(ctx context.Context, old Datastore, next Datastore)) *MockDatastoresService_Update_Call {
+func (_c *MockVPCsService_Update_Call) Run(run func(ctx context.Context, vpc VPC)) *MockVPCsService_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 Datastore
+		var arg1 VPC
 		if args[1] != nil {
-			arg1 = args[1].(Datastore)
-		}
-		var arg2 Datastore
-		if args[2] != nil {
-			arg2 = args[2].(Datastore)
+			arg1 = args[1].(VPC)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDatastoresService_Update_Call) Return(datastore *Datastore, err error) *MockDatastoresService_Update_Call {
-	_c.Call.Return(datastore, err)
+func (_c *MockVPCsService_Update_Call) Return(vPC *VPC, err error) *MockVPCsService_Update_Call {
+	_c.Call.Return(vPC, err)
 	return _c
 }
 
-func (_c *MockDatastoresService_Update_Call) RunAndReturn(run func(ctx context.Context, old Datastore, next Datastore) (*Datastore, error)) *MockDatastoresService_Update_Call {
+func (_c *MockVPCsService_Update_Call) RunAndReturn(run func(ctx context.Context, vpc VPC) (*VPC, error)) *MockVPCsService_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockVPCsService creates a new instance of MockVPCsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockVPCPeeringsService creates a new instance of MockVPCPeeringsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockVPCsService(t interface {
+func NewMockVPCPeeringsService(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockVPCsService {
-	mock := &MockVPCsService{}
+}) *MockVPCPeeringsService {
+	mock := &MockVPCPeeringsService{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -678,68 +1604,68 @@ func NewMockVPCsService(t interface {
 	return mock
 }
 
-// MockVPCsService is an autogenerated mock type for the VPCsService type
-type MockVPCsService struct {
+// MockVPCPeeringsService is an autogenerated mock type for the VPCPeeringsService type
+type MockVPCPeeringsService struct {
 	mock.Mock
 }
 
-type MockVPCsService_Expecter struct {
+type MockVPCPeeringsService_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockVPCsService) EXPECT() *MockVPCsService_Expecter {
-	return &MockVPCsService_Expecter{mock: &_m.Mock}
+func (_m *MockVPCPeeringsService) EXPECT() *MockVPCPeeringsService_Expecter {
+	return &MockVPCPeeringsService_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function for the type MockVPCsService
-func (_mock *MockVPCsService) Create(ctx context.Context, vpc VPC) (*VPC, error) {
-	ret := _mock.Called(ctx, vpc)
+// Create provides a mock function for the type MockVPCPeeringsService
+func (_mock *MockVPCPeeringsService) Create(ctx context.Context, p VPCPeering) (*VPCPeering, error) {
+	ret := _mock.Called(ctx, p)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
-	var r0 *VPC
+	var r0 *VPCPeering
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, VPC) (*VPC, error)); ok {
-		return returnFunc(ctx, vpc)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, VPCPeering) (*VPCPeering, error)); ok {
+		return returnFunc(ctx, p)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, VPC) *VPC); ok {
-		r0 = returnFunc(ctx, vpc)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, VPCPeering) *VPCPeering); ok {
+		r0 = returnFunc(ctx, p)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*VPC)
+			r0 = ret.Get(0).(*VPCPeering)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, VPC) error); ok {
-		r1 = returnFunc(ctx, vpc)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, VPCPeering) error); ok {
+		r1 = returnFunc(ctx, p)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockVPCsService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockVPCsService_Create_Call struct {
+// MockVPCPeeringsService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockVPCPeeringsService_Create_Call struct {
 	*mock.Call
 }
 
 // Create is a helper method to define mock.On call
 //   - ctx context.Context
-//   - vpc VPC
-func (_e *MockVPCsService_Expecter) Create(ctx interface{}, vpc interface{}) *MockVPCsService_Create_Call {
-	return &MockVPCsService_Create_Call{Call: _e.mock.On("Create", ctx, vpc)}
+//   - p VPCPeering
+func (_e *MockVPCPeeringsService_Expecter) Create(ctx interface{}, p interface{}) *MockVPCPeeringsService_Create_Call {
+	return &MockVPCPeeringsService_Create_Call{Call: _e.mock.On("Create", ctx, p)}
 }
 
-func (_c *MockVPCsService_Create_Call) Run(run func(ctx context.Context, vpc VPC)) *MockVPCsService_Create_Call {
+func (_c *MockVPCPeeringsService_Create_Call) Run(run func(ctx context.Context, p VPCPeering)) *MockVPCPeeringsService_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 VPC
+		var arg1 VPCPeering
 		if args[1] != nil {
-			arg1 = args[1].(VPC)
+			arg1 = args[1].(VPCPeering)
 		}
 		run(
 			arg0,
@@ -749,46 +1675,47 @@ func (_c *MockVPCsService_Create_Call) Run(run func(ctx context.Context, vpc VPC
 	return _c
 }
 
-func (_c *MockVPCsService_Create_Call) Return(vPC *VPC, err error) *MockVPCsService_Create_Call {
-	_c.Call.Return(vPC, err)
+func (_c *MockVPCPeeringsService_Create_Call) Return(vPCPeering *VPCPeering, err error) *MockVPCPeeringsService_Create_Call {
+	_c.Call.Return(vPCPeering, err)
 	return _c
 }
 
-func (_c *MockVPCsService_Create_Call) RunAndReturn(run func(ctx context.Context, vpc VPC) (*VPC, error)) *MockVPCsService_Create_Call {
+func (_c *MockVPCPeeringsService_Create_Call) RunAndReturn(run func(ctx context.Context, p VPCPeering) (*VPCPeering, error)) *MockVPCPeeringsService_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Delete provides a mock function for the type MockVPCsService
-func (_mock *MockVPCsService) Delete(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// Delete provides a mock function for the type MockVPCPeeringsService
+func (_mock *MockVPCPeeringsService) Delete(ctx context.Context, vpcID string, id string) error {
+	ret := _mock.Called(ctx, vpcID, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, vpcID, id)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockVPCsService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockVPCsService_Delete_Call struct {
+// MockVPCPeeringsService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockVPCPeeringsService_Delete_Call struct {
 	*mock.Call
 }
 
 // Delete is a helper method to define mock.On call
 //   - ctx context.Context
+//   - vpcID string
 //   - id string
-func (_e *MockVPCsService_Expecter) Delete(ctx interface{}, id interface{}) *MockVPCsService_Delete_Call {
-	return &MockVPCsService_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+func (_e *MockVPCPeeringsService_Expecter) Delete(ctx interface{}, vpcID interface{}, id interface{}) *MockVPCPeeringsService_Delete_Call {
+	return &MockVPCPeeringsService_Delete_Call{Call: _e.mock.On("Delete", ctx, vpcID, id)}
 }
 
-func (_c *MockVPCsService_Delete_Call) Run(run func(ctx context.Context, id string)) *MockVPCsService_Delete_Call {
+func (_c *MockVPCPeeringsService_Delete_Call) Run(run func(ctx context.Context, vpcID string, id string)) *MockVPCPeeringsService_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -798,65 +1725,71 @@ func (_c *MockVPCsService_Delete_Call) Run(run func(ctx context.Context, id stri
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockVPCsService_Delete_Call) Return(err error) *MockVPCsService_Delete_Call {
+func (_c *MockVPCPeeringsService_Delete_Call) Return(err error) *MockVPCPeeringsService_Delete_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockVPCsService_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockVPCsService_Delete_Call {
+func (_c *MockVPCPeeringsService_Delete_Call) RunAndReturn(run func(ctx context.Context, vpcID string, id string) error) *MockVPCPeeringsService_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Read provides a mock function for the type MockVPCsService
-func (_mock *MockVPCsService) Read(ctx context.Context, id string) (*VPC, error) {
-	ret := _mock.Called(ctx, id)
+// Read provides a mock function for the type MockVPCPeeringsService
+func (_mock *MockVPCPeeringsService) Read(ctx context.Context, vpcID string, id string) (*VPCPeering, error) {
+	ret := _mock.Called(ctx, vpcID, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Read")
 	}
 
-	var r0 *VPC
+	var r0 *VPCPeering
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*VPC, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*VPCPeering, error)); ok {
+		return returnFunc(ctx, vpcID, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *VPC); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *VPCPeering); ok {
+		r0 = returnFunc(ctx, vpcID, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*VPC)
+			r0 = ret.Get(0).(*VPCPeering)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, vpcID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockVPCsService_Read_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Read'
-type MockVPCsService_Read_Call struct {
+// MockVPCPeeringsService_Read_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Read'
+type MockVPCPeeringsService_Read_Call struct {
 	*mock.Call
 }
 
 // Read is a helper method to define mock.On call
 //   - ctx context.Context
+//   - vpcID string
 //   - id string
-func (_e *MockVPCsService_Expecter) Read(ctx interface{}, id interface{}) *MockVPCsService_Read_Call {
-	return &MockVPCsService_Read_Call{Call: _e.mock.On("Read", ctx, id)}
+func (_e *MockVPCPeeringsService_Expecter) Read(ctx interface{}, vpcID interface{}, id interface{}) *MockVPCPeeringsService_Read_Call {
+	return &MockVPCPeeringsService_Read_Call{Call: _e.mock.On("Read", ctx, vpcID, id)}
 }
 
-func (_c *MockVPCsService_Read_Call) Run(run func(ctx context.Context, id string)) *MockVPCsService_Read_Call {
+func (_c *MockVPCPeeringsService_Read_Call) Run(run func(ctx context.Context, vpcID string, id string)) *MockVPCPeeringsService_Read_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -866,88 +1799,25 @@ func (_c *MockVPCsService_Read_Call) Run(run func(ctx context.Context, id string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		run(
-			arg0,
-			arg1,
-		)
-	})
-	return _c
-}
-
-func (_c *MockVPCsService_Read_Call) Return(vPC *VPC, err error) *MockVPCsService_Read_Call {
-	_c.Call.Return(vPC, err)
-	return _c
-}
-
-func (_c *MockVPCsService_Read_Call) RunAndReturn(run func(ctx context.Context, id string) (*VPC, error)) *MockVPCsService_Read_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// Update provides a mock function for the type MockVPCsService
-func (_mock *MockVPCsService) Update(ctx context.Context, vpc VPC) (*VPC, error) {
-	ret := _mock.Called(ctx, vpc)
-
-	if len(ret) == 0 {
-		panic("no return value specified for Update")
-	}
-
-	var r0 *VPC
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, VPC) (*VPC, error)); ok {
-		return returnFunc(ctx, vpc)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, VPC) *VPC); ok {
-		r0 = returnFunc(ctx, vpc)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*VPC)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, VPC) error); ok {
-		r1 = returnFunc(ctx, vpc)
-	} else {
-		r1 = ret.Error(1)
-	}
-	return r0, r1
-}
-
-// MockVPCsService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockVPCsService_Update_Call struct {
-	*mock.Call
-}
-
-// Update is a helper method to define mock.On call
-//   - ctx context.Context
-//   - vpc VPC
-func (_e *MockVPCsService_Expecter) Update(ctx interface{}, vpc interface{}) *MockVPCsService_Update_Call {
-	return &MockVPCsService_Update_Call{Call: _e.mock.On("Update", ctx, vpc)}
-}
-
-func (_c *MockVPCsService_Update_Call) Run(run func(ctx context.Context, vpc VPC)) *MockVPCsService_Update_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
-		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 VPC
-		if args[1] != nil {
-			arg1 = args[1].(VPC)
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockVPCsService_Update_Call) Return(vPC *VPC, err error) *MockVPCsService_Update_Call {
-	_c.Call.Return(vPC, err)
+func (_c *MockVPCPeeringsService_Read_Call) Return(vPCPeering *VPCPeering, err error) *MockVPCPeeringsService_Read_Call {
+	_c.Call.Return(vPCPeering, err)
 	return _c
 }
 
-func (_c *MockVPCsService_Update_Call) RunAndReturn(run func(ctx context.Context, vpc VPC) (*VPC, error)) *MockVPCsService_Update_Call {
+func (_c *MockVPCPeeringsService_Read_Call) RunAndReturn(run func(ctx context.Context, vpcID string, id string) (*VPCPeering, error)) *MockVPCPeeringsService_Read_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1110,69 +1980,137 @@ func (_c *MockContentService_DBVendors_Call) Return(dBVendorInfos []DBVendorInfo
 	return _c
 }
 
-func (_c *MockContentService_DBVendors_Call) RunAndReturn(run func(ctx context.Context) ([]DBVendorInfo, error)) *MockContentService_DBVendors_Call {
+func (_c *MockContentService_DBVendors_Call) RunAndReturn(run func(ctx context.Context) ([]DBVendorInfo, error)) *MockContentService_DBVendors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InstanceSizes provides a mock function for the type MockContentService
+func (_mock *MockContentService) InstanceSizes(ctx context.Context) (map[string][]InstanceSize, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InstanceSizes")
+	}
+
+	var r0 map[string][]InstanceSize
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (map[string][]InstanceSize, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) map[string][]InstanceSize); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]InstanceSize)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockContentService_InstanceSizes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstanceSizes'
+type MockContentService_InstanceSizes_Call struct {
+	*mock.Call
+}
+
+// InstanceSizes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockContentService_Expecter) InstanceSizes(ctx interface{}) *MockContentService_InstanceSizes_Call {
+	return &MockContentService_InstanceSizes_Call{Call: _e.mock.On("InstanceSizes", ctx)}
+}
+
+func (_c *MockContentService_InstanceSizes_Call) Run(run func(ctx context.Context)) *MockContentService_InstanceSizes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockContentService_InstanceSizes_Call) Return(stringToInstanceSizes map[string][]InstanceSize, err error) *MockContentService_InstanceSizes_Call {
+	_c.Call.Return(stringToInstanceSizes, err)
+	return _c
+}
+
+func (_c *MockContentService_InstanceSizes_Call) RunAndReturn(run func(ctx context.Context) (map[string][]InstanceSize, error)) *MockContentService_InstanceSizes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InstanceSizes provides a mock function for the type MockContentService
-func (_mock *MockContentService) InstanceSizes(ctx context.Context) (map[string][]InstanceSize, error) {
-	ret := _mock.Called(ctx)
+// VolumeLimits provides a mock function for the type MockContentService
+func (_mock *MockContentService) VolumeLimits(ctx context.Context, cloud string) (map[string]VolumeLimits, error) {
+	ret := _mock.Called(ctx, cloud)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InstanceSizes")
+		panic("no return value specified for VolumeLimits")
 	}
 
-	var r0 map[string][]InstanceSize
+	var r0 map[string]VolumeLimits
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (map[string][]InstanceSize, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (map[string]VolumeLimits, error)); ok {
+		return returnFunc(ctx, cloud)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) map[string][]InstanceSize); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) map[string]VolumeLimits); ok {
+		r0 = returnFunc(ctx, cloud)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string][]InstanceSize)
+			r0 = ret.Get(0).(map[string]VolumeLimits)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, cloud)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockContentService_InstanceSizes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstanceSizes'
-type MockContentService_InstanceSizes_Call struct {
+// MockContentService_VolumeLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VolumeLimits'
+type MockContentService_VolumeLimits_Call struct {
 	*mock.Call
 }
 
-// InstanceSizes is a helper method to define mock.On call
+// VolumeLimits is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockContentService_Expecter) InstanceSizes(ctx interface{}) *MockContentService_InstanceSizes_Call {
-	return &MockContentService_InstanceSizes_Call{Call: _e.mock.On("InstanceSizes", ctx)}
+//   - cloud string
+func (_e *MockContentService_Expecter) VolumeLimits(ctx interface{}, cloud interface{}) *MockContentService_VolumeLimits_Call {
+	return &MockContentService_VolumeLimits_Call{Call: _e.mock.On("VolumeLimits", ctx, cloud)}
 }
 
-func (_c *MockContentService_InstanceSizes_Call) Run(run func(ctx context.Context)) *MockContentService_InstanceSizes_Call {
+func (_c *MockContentService_VolumeLimits_Call) Run(run func(ctx context.Context, cloud string)) *MockContentService_VolumeLimits_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockContentService_InstanceSizes_Call) Return(stringToInstanceSizes map[string][]InstanceSize, err error) *MockContentService_InstanceSizes_Call {
-	_c.Call.Return(stringToInstanceSizes, err)
+func (_c *MockContentService_VolumeLimits_Call) Return(stringToVolumeLimits map[string]VolumeLimits, err error) *MockContentService_VolumeLimits_Call {
+	_c.Call.Return(stringToVolumeLimits, err)
 	return _c
 }
 
-func (_c *MockContentService_InstanceSizes_Call) RunAndReturn(run func(ctx context.Context) (map[string][]InstanceSize, error)) *MockContentService_InstanceSizes_Call {
+func (_c *MockContentService_VolumeLimits_Call) RunAndReturn(run func(ctx context.Context, cloud string) (map[string]VolumeLimits, error)) *MockContentService_VolumeLimits_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1397,6 +2335,68 @@ func (_c *MockParameterGroupsService_Delete_Call) RunAndReturn(run func(ctx cont
 	return _c
 }
 
+// List provides a mock function for the type MockParameterGroupsService
+func (_mock *MockParameterGroupsService) List(ctx context.Context) ([]ParameterGroup, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []ParameterGroup
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]ParameterGroup, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []ParameterGroup); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ParameterGroup)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockParameterGroupsService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockParameterGroupsService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockParameterGroupsService_Expecter) List(ctx interface{}) *MockParameterGroupsService_List_Call {
+	return &MockParameterGroupsService_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockParameterGroupsService_List_Call) Run(run func(ctx context.Context)) *MockParameterGroupsService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockParameterGroupsService_List_Call) Return(parameterGroups []ParameterGroup, err error) *MockParameterGroupsService_List_Call {
+	_c.Call.Return(parameterGroups, err)
+	return _c
+}
+
+func (_c *MockParameterGroupsService_List_Call) RunAndReturn(run func(ctx context.Context) ([]ParameterGroup, error)) *MockParameterGroupsService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Read provides a mock function for the type MockParameterGroupsService
 func (_mock *MockParameterGroupsService) Read(ctx context.Context, id string) (*ParameterGroup, error) {
 	ret := _mock.Called(ctx, id)
@@ -1621,6 +2621,238 @@ func (_c *MockJobsService_Await_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// AwaitNew provides a mock function for the type MockJobsService
+func (_mock *MockJobsService) AwaitNew(ctx context.Context, storeID string, job JobType, previousID string) (JobStatus, error) {
+	ret := _mock.Called(ctx, storeID, job, previousID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AwaitNew")
+	}
+
+	var r0 JobStatus
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, JobType, string) (JobStatus, error)); ok {
+		return returnFunc(ctx, storeID, job, previousID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, JobType, string) JobStatus); ok {
+		r0 = returnFunc(ctx, storeID, job, previousID)
+	} else {
+		r0 = ret.Get(0).(JobStatus)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, JobType, string) error); ok {
+		r1 = returnFunc(ctx, storeID, job, previousID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobsService_AwaitNew_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AwaitNew'
+type MockJobsService_AwaitNew_Call struct {
+	*mock.Call
+}
+
+// AwaitNew is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storeID string
+//   - job JobType
+//   - previousID string
+func (_e *MockJobsService_Expecter) AwaitNew(ctx interface{}, storeID interface{}, job interface{}, previousID interface{}) *MockJobsService_AwaitNew_Call {
+	return &MockJobsService_AwaitNew_Call{Call: _e.mock.On("AwaitNew", ctx, storeID, job, previousID)}
+}
+
+func (_c *MockJobsService_AwaitNew_Call) Run(run func(ctx context.Context, storeID string, job JobType, previousID string)) *MockJobsService_AwaitNew_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 JobType
+		if args[2] != nil {
+			arg2 = args[2].(JobType)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobsService_AwaitNew_Call) Return(jobStatus JobStatus, err error) *MockJobsService_AwaitNew_Call {
+	_c.Call.Return(jobStatus, err)
+	return _c
+}
+
+func (_c *MockJobsService_AwaitNew_Call) RunAndReturn(run func(ctx context.Context, storeID string, job JobType, previousID string) (JobStatus, error)) *MockJobsService_AwaitNew_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockJobsService
+func (_mock *MockJobsService) Get(ctx context.Context, storeID string, job JobType) (*Job, error) {
+	ret := _mock.Called(ctx, storeID, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, JobType) (*Job, error)); ok {
+		return returnFunc(ctx, storeID, job)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, JobType) *Job); ok {
+		r0 = returnFunc(ctx, storeID, job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, JobType) error); ok {
+		r1 = returnFunc(ctx, storeID, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobsService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockJobsService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storeID string
+//   - job JobType
+func (_e *MockJobsService_Expecter) Get(ctx interface{}, storeID interface{}, job interface{}) *MockJobsService_Get_Call {
+	return &MockJobsService_Get_Call{Call: _e.mock.On("Get", ctx, storeID, job)}
+}
+
+func (_c *MockJobsService_Get_Call) Run(run func(ctx context.Context, storeID string, job JobType)) *MockJobsService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 JobType
+		if args[2] != nil {
+			arg2 = args[2].(JobType)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobsService_Get_Call) Return(job1 *Job, err error) *MockJobsService_Get_Call {
+	_c.Call.Return(job1, err)
+	return _c
+}
+
+func (_c *MockJobsService_Get_Call) RunAndReturn(run func(ctx context.Context, storeID string, job JobType) (*Job, error)) *MockJobsService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNew provides a mock function for the type MockJobsService
+func (_mock *MockJobsService) GetNew(ctx context.Context, storeID string, job JobType, previousID string) (*Job, error) {
+	ret := _mock.Called(ctx, storeID, job, previousID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNew")
+	}
+
+	var r0 *Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, JobType, string) (*Job, error)); ok {
+		return returnFunc(ctx, storeID, job, previousID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, JobType, string) *Job); ok {
+		r0 = returnFunc(ctx, storeID, job, previousID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, JobType, string) error); ok {
+		r1 = returnFunc(ctx, storeID, job, previousID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobsService_GetNew_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNew'
+type MockJobsService_GetNew_Call struct {
+	*mock.Call
+}
+
+// GetNew is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storeID string
+//   - job JobType
+//   - previousID string
+func (_e *MockJobsService_Expecter) GetNew(ctx interface{}, storeID interface{}, job interface{}, previousID interface{}) *MockJobsService_GetNew_Call {
+	return &MockJobsService_GetNew_Call{Call: _e.mock.On("GetNew", ctx, storeID, job, previousID)}
+}
+
+func (_c *MockJobsService_GetNew_Call) Run(run func(ctx context.Context, storeID string, job JobType, previousID string)) *MockJobsService_GetNew_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 JobType
+		if args[2] != nil {
+			arg2 = args[2].(JobType)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobsService_GetNew_Call) Return(job1 *Job, err error) *MockJobsService_GetNew_Call {
+	_c.Call.Return(job1, err)
+	return _c
+}
+
+func (_c *MockJobsService_GetNew_Call) RunAndReturn(run func(ctx context.Context, storeID string, job JobType, previousID string) (*Job, error)) *MockJobsService_GetNew_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetStatus provides a mock function for the type MockJobsService
 func (_mock *MockJobsService) GetStatus(context1 context.Context, storeID string, job JobType) (JobStatus, error) {
 	ret := _mock.Called(context1, storeID, job)
@@ -75,6 +75,21 @@ func (svc *ParameterGroupsClient) Read(ctx context.Context, id string) (*Paramet
 	return &rs, nil
 }
 
+type listParameterGroupsResponse struct {
+	ParameterGroups []ParameterGroup `json:"parameter_groups"`
+}
+
+func (svc *ParameterGroupsClient) List(ctx context.Context) ([]ParameterGroup, error) {
+	var rs listParameterGroupsResponse
+
+	err := svc.client.Get(ctx, "/api/db-configuration/v1/parameter-groups", &rs)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs.ParameterGroups, nil
+}
+
 type updateParameterGroupRequest struct {
 	Name        string            `json:"name"`
 	Description string            `json:"description"`
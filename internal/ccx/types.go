@@ -2,7 +2,9 @@ package ccx
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 )
@@ -29,7 +31,9 @@ type Datastore struct {
 	VolumeType        string
 	VolumeSize        uint64
 	VolumeIOPS        uint64
+	VolumeThroughput  uint64
 	HAEnabled         bool
+	SSLEnabled        bool
 	VpcUUID           string
 	ParameterGroupID  string
 	AvailabilityZones []string
@@ -37,31 +41,47 @@ type Datastore struct {
 	FirewallRules []FirewallRule
 	Hosts         []Host
 
+	// RemoveNodeIDs and AddNodes choose the nodes to remove or add when Size changes, instead of choosing them automatically
+	RemoveNodeIDs []string
+	AddNodes      []NodeSpec
+
 	Notifications       Notifications
 	MaintenanceSettings *MaintenanceSettings
+	StorageAutoscale    *StorageAutoscale
 
 	PrimaryUrl string
 	PrimaryDsn string
 	ReplicaUrl string
 	ReplicaDsn string
-	Username   string
-	Password   string
-	DbName     string
+	Connection Connection
+	// CACertificate is the PEM encoded CA certificate of the datastore, when SSL is enabled
+	CACertificate string
+	Username      string
+	Password      string
+	DbName        string
 }
 
 type Host struct {
 	ID            string
 	CreatedAt     time.Time
 	CloudProvider string
+	Hostname      string
 	AZ            string
 	InstanceType  string
 	DiskType      string
 	DiskSize      uint64
 	Role          string
+	Status        string
 	Region        string
 	Port          int
 }
 
+// NodeSpec describes a node to add to a datastore
+type NodeSpec struct {
+	AZ           string
+	InstanceSize string
+}
+
 func (h Host) IsPrimary() bool {
 	switch r := strings.ToLower(h.Role); r {
 	case "primary", "master":
@@ -71,6 +91,41 @@ func (h Host) IsPrimary() bool {
 	return false
 }
 
+// IsReplica reports whether the host is a replica of the primary
+func (h Host) IsReplica() bool {
+	switch r := strings.ToLower(h.Role); r {
+	case "replica", "slave":
+		return true
+	}
+
+	return false
+}
+
+// IsHealthy reports whether the host is reported as up and running
+func (h Host) IsHealthy() bool {
+	switch s := strings.ToLower(h.Status); s {
+	case "ok", "healthy", "online", "running":
+		return true
+	}
+
+	return false
+}
+
+// UserTags returns the tags of the datastore, without the ones which CCX adds on its own
+func (c Datastore) UserTags() []string {
+	auto := []string{c.DBVendor, c.DBVersion, c.Type, c.CloudProvider, c.CloudRegion}
+
+	ls := make([]string, 0, len(c.Tags))
+
+	for _, t := range c.Tags {
+		if !slices.ContainsFunc(auto, func(a string) bool { return strings.EqualFold(a, t) }) {
+			ls = append(ls, t)
+		}
+	}
+
+	return ls
+}
+
 // String representation of the Datastore, useful for debugging
 func (c Datastore) String() string {
 	return fmt.Sprintf(`{"id": "%s", "name": "%s"}`, c.ID, c.Name)
@@ -79,10 +134,48 @@ func (c Datastore) String() string {
 type FirewallRule struct {
 	Source      string `json:"source"`
 	Description string `json:"description"`
+	// Ports are the names of the services the source has access to, AllPorts opens all ports
+	Ports []string `json:"ports,omitempty"`
 }
 
 func (f FirewallRule) String() string {
-	return fmt.Sprintf(`{"source": "%s", "description": "%s"}`, f.Source, f.Description)
+	return fmt.Sprintf(`{"source": "%s", "description": "%s", "ports": [%s]}`, f.Source, f.Description, strings.Join(f.Ports, ", "))
+}
+
+// AllPorts as the only port opens all ports to the source, the rule is created without ports,
+// and CCX lists the ports of the datastore as the ports of the rule
+const AllPorts = "*"
+
+// OpensAllPorts reports whether the ports of the rule are AllPorts
+func (f FirewallRule) OpensAllPorts() bool {
+	return len(f.Ports) == 1 && f.Ports[0] == AllPorts
+}
+
+// IPSetRulePrefix marks the firewall rules of a ccx_ip_set, the name of the set follows it in the description of the rule
+const IPSetRulePrefix = "ccx_ip_set:"
+
+// IPSet returns the name of the ip set the rule belongs to
+func (f FirewallRule) IPSet() (string, bool) {
+	return strings.CutPrefix(f.Description, IPSetRulePrefix)
+}
+
+// Matches reports whether both rules are the same, sources are compared as CIDRs, and ports are compared exactly when both are set.
+// Ports which are not set match any ports, e.g. a rule without ports in the configuration matches the rule with the ports read from CCX.
+// AllPorts only matches AllPorts, as CCX lists the ports instead, so that a rule with other ports is replaced by one opening all ports.
+func (f FirewallRule) Matches(o FirewallRule) bool {
+	if !sameCIDR(f.Source, o.Source) || f.Description != o.Description {
+		return false
+	}
+
+	if len(f.Ports) == 0 || len(o.Ports) == 0 {
+		return true
+	}
+
+	a, b := slices.Clone(f.Ports), slices.Clone(o.Ports)
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(a, b)
 }
 
 type Notifications struct {
@@ -96,15 +189,31 @@ type MaintenanceSettings struct {
 	EndHour   int   `json:"end_hour"`
 }
 
+// StorageAutoscale settings, CCX grows the volume when it is filled up to ThresholdPct, but not beyond MaxSize
+type StorageAutoscale struct {
+	Enabled      bool   `json:"enabled"`
+	MaxSize      uint64 `json:"max_size"`
+	ThresholdPct int    `json:"threshold_pct"`
+}
+
 // DatastoresService is used to manage datastores
 type DatastoresService interface {
 	Create(ctx context.Context, c Datastore) (*Datastore, error)
 	Read(ctx context.Context, id string) (*Datastore, error)
+	List(ctx context.Context) ([]Datastore, error)
+	ListInVPC(ctx context.Context, vpcID string) ([]Datastore, error)
 	Update(ctx context.Context, old, next Datastore) (*Datastore, error)
 	Delete(ctx context.Context, id string) error
+	GetFirewallRules(ctx context.Context, storeID string) ([]FirewallRule, error)
+	CreateFirewallRule(ctx context.Context, storeID string, firewall FirewallRule) error
+	DeleteFirewallRule(ctx context.Context, storeID string, firewall FirewallRule) error
 	SetFirewallRules(ctx context.Context, storeID string, firewalls []FirewallRule) error
+	SetIPSetFirewallRules(ctx context.Context, storeID, name string, firewalls []FirewallRule) error
 	SetMaintenanceSettings(ctx context.Context, storeID string, settings MaintenanceSettings) error
 	ApplyParameterGroup(ctx context.Context, id, group string) error
+	UpgradeDBVersion(ctx context.Context, c Datastore, version string) error
+	PromoteReplica(ctx context.Context, storeID, hostID string) error
+	RotatePassword(ctx context.Context, storeID string) error
 }
 
 type VPC struct {
@@ -114,6 +223,28 @@ type VPC struct {
 	CloudProvider string `json:"cloud"`
 	Region        string `json:"region"`
 	CidrIpv4Block string `json:"cidr_ipv4_block"`
+	Status        string `json:"status"`
+}
+
+// Ready reports whether the VPC can be used, e.g. by datastores.
+// A VPC without status is ready, as not all CCX versions report it.
+func (v VPC) Ready() bool {
+	switch strings.ToLower(v.Status) {
+	case "", "ready", "active", "available":
+		return true
+	}
+
+	return false
+}
+
+// Failed reports whether creating the VPC failed
+func (v VPC) Failed() bool {
+	switch strings.ToLower(v.Status) {
+	case "failed", "error", "create_failed":
+		return true
+	}
+
+	return false
 }
 
 // String representation of the VPC, useful for debugging
@@ -124,10 +255,58 @@ func (v VPC) String() string {
 type VPCsService interface {
 	Create(ctx context.Context, vpc VPC) (*VPC, error)
 	Read(ctx context.Context, id string) (*VPC, error)
+	List(ctx context.Context) ([]VPC, error)
 	Update(ctx context.Context, vpc VPC) (*VPC, error)
 	Delete(ctx context.Context, id string) error
 }
 
+// VPCPeering connects a CCX VPC to a VPC of another cloud account, e.g. the VPC of the applications.
+// The peering is requested by CCX and accepted on the peer side, using the Cloud* identifiers.
+type VPCPeering struct {
+	ID                 string
+	VpcID              string
+	PeerCloudAccountID string
+	PeerVpcID          string
+	PeerCidrIpv4Block  string
+	PeerRegion         string
+	Status             string
+	StatusMessage      string
+	CloudPeeringID     string // ID of the peering in the cloud, e.g. pcx-... on AWS
+	CloudAccountID     string // account or project of the CCX VPC in the cloud
+	CloudVpcID         string // ID or network name of the CCX VPC in the cloud
+}
+
+// Requested reports whether the peering was requested in the cloud, i.e. it can be accepted on the peer side using the Cloud* identifiers.
+// An active peering is requested, as GCP has no ID of the peering.
+func (p VPCPeering) Requested() bool {
+	if strings.EqualFold(p.Status, "active") {
+		return true
+	}
+
+	return p.CloudPeeringID != "" && p.CloudAccountID != "" && p.CloudVpcID != ""
+}
+
+// Failed reports whether the peering failed, e.g. it was rejected on the peer side, StatusMessage has the details
+func (p VPCPeering) Failed() bool {
+	switch strings.ToLower(p.Status) {
+	case "failed", "error", "rejected", "expired", "create_failed":
+		return true
+	}
+
+	return false
+}
+
+// String representation of the VPC peering, useful for debugging
+func (p VPCPeering) String() string {
+	return fmt.Sprintf(`{"id": "%s", "vpc_id": "%s", "peer_vpc_id": "%s"}`, p.ID, p.VpcID, p.PeerVpcID)
+}
+
+type VPCPeeringsService interface {
+	Create(ctx context.Context, p VPCPeering) (*VPCPeering, error)
+	Read(ctx context.Context, vpcID, id string) (*VPCPeering, error)
+	Delete(ctx context.Context, vpcID, id string) error
+}
+
 type InstanceSize struct {
 	Code string `json:"code"`
 	Type string `json:"type"`
@@ -147,11 +326,20 @@ type DBVendorInfo struct {
 	NumNodes       []int
 }
 
+// VolumeLimits are the allowed IOPS and throughput of a volume type, a zero maximum means that it cannot be configured
+type VolumeLimits struct {
+	MinIOPS       uint64
+	MaxIOPS       uint64
+	MinThroughput uint64
+	MaxThroughput uint64
+}
+
 type ContentService interface {
 	InstanceSizes(ctx context.Context) (map[string][]InstanceSize, error)
 	AvailabilityZones(ctx context.Context, provider, region string) ([]string, error)
 	DBVendors(ctx context.Context) ([]DBVendorInfo, error)
 	VolumeTypes(ctx context.Context, cloud string) ([]string, error)
+	VolumeLimits(ctx context.Context, cloud string) (map[string]VolumeLimits, error)
 }
 
 type ParameterGroup struct {
@@ -167,6 +355,7 @@ type ParameterGroup struct {
 type ParameterGroupsService interface {
 	Create(ctx context.Context, p ParameterGroup) (*ParameterGroup, error)
 	Read(ctx context.Context, id string) (*ParameterGroup, error)
+	List(ctx context.Context) ([]ParameterGroup, error)
 	Update(ctx context.Context, p ParameterGroup) error
 	Delete(ctx context.Context, id string) error
 }
@@ -179,6 +368,11 @@ const (
 	DestroyStoreJob   JobType = "JOB_TYPE_DESTROY_DATASTORE"
 	AddNodeJob        JobType = "JOB_TYPE_ADD_NODE"
 	RemoveNodeJob     JobType = "JOB_TYPE_REMOVE_NODE"
+	UpgradeDBJob      JobType = "JOB_TYPE_UPGRADE_DATABASE"
+	PromoteReplicaJob JobType = "JOB_TYPE_PROMOTE_REPLICA"
+	InstanceSizeJob   JobType = "JOB_TYPE_MODIFY_INSTANCE_SIZE"
+	ModifyVolumeJob   JobType = "JOB_TYPE_MODIFY_VOLUME"
+	RotatePasswordJob JobType = "JOB_TYPE_ROTATE_DB_PASSWORD"
 )
 
 type JobStatus string
@@ -190,7 +384,46 @@ const (
 	JobStatusErrored  JobStatus = "JOB_STATUS_ERRORED"
 )
 
+// Job is the latest job of a type, with the status of each node it runs on
+type Job struct {
+	ID     string
+	Type   JobType
+	Status JobStatus
+	Error  string
+	Nodes  []JobNode
+}
+
+type JobNode struct {
+	HostID string
+	Status JobStatus
+	Error  string
+}
+
+// Err returns the errors of a failed job and its nodes, or nil if the job has not failed
+func (j Job) Err() error {
+	if j.Status != JobStatusErrored {
+		return nil
+	}
+
+	errs := []error{fmt.Errorf("%w: %s", ErrJobFailed, j.Type)}
+
+	if j.Error != "" {
+		errs[0] = fmt.Errorf("%w: %s: %s", ErrJobFailed, j.Type, j.Error)
+	}
+
+	for _, n := range j.Nodes {
+		if n.Status == JobStatusErrored {
+			errs = append(errs, fmt.Errorf("node %s: %s", n.HostID, n.Error))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 type JobsService interface {
 	Await(ctx context.Context, storeID string, job JobType) (JobStatus, error)
+	AwaitNew(ctx context.Context, storeID string, job JobType, previousID string) (JobStatus, error)
 	GetStatus(_ context.Context, storeID string, job JobType) (JobStatus, error)
+	Get(ctx context.Context, storeID string, job JobType) (*Job, error)
+	GetNew(ctx context.Context, storeID string, job JobType, previousID string) (*Job, error)
 }
@@ -0,0 +1,20 @@
+package ccx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatastore_UserTags(t *testing.T) {
+	c := Datastore{
+		DBVendor:      "percona",
+		DBVersion:     "8",
+		Type:          "replication",
+		CloudProvider: "aws",
+		CloudRegion:   "eu-north-1",
+		Tags:          []string{"new", "test", "percona", "8", "Replication", "aws", "eu-north-1"},
+	}
+
+	assert.Equal(t, []string{"new", "test"}, c.UserTags())
+}
@@ -1,25 +1,34 @@
 package ccx
 
+import (
+	"time"
+)
+
 type VPCsClient struct {
 	httpcli HTTPClient
+	tick    time.Duration // time to wait between vpc status checks
 }
 
 // NewVPCsClient creates a new VPC VpcService
 func NewVPCsClient(httpcli HTTPClient) VPCsService {
 	return &VPCsClient{
 		httpcli: httpcli,
+		tick:    time.Second * 10,
 	}
 }
 
+type vpcResponseItem struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Cloudspace    string `json:"cloudspace"`
+	CloudProvider string `json:"cloud"`
+	Region        string `json:"region"`
+	CidrIpv4Block string `json:"cidr_ipv4_block"`
+	Status        string `json:"status"`
+}
+
 type vpcResponse struct {
-	VPC *struct {
-		ID            string `json:"id"`
-		Name          string `json:"name"`
-		Cloudspace    string `json:"cloudspace"`
-		CloudProvider string `json:"cloud"`
-		Region        string `json:"region"`
-		CidrIpv4Block string `json:"cidr_ipv4_block"`
-	} `json:"vpc"`
+	VPC *vpcResponseItem `json:"vpc"`
 }
 
 func vpcFromResponse(r vpcResponse) VPC {
@@ -27,12 +36,17 @@ func vpcFromResponse(r vpcResponse) VPC {
 		return VPC{}
 	}
 
+	return vpcFromResponseItem(*r.VPC)
+}
+
+func vpcFromResponseItem(r vpcResponseItem) VPC {
 	return VPC{
-		ID:            r.VPC.ID,
-		Name:          r.VPC.Name,
-		CloudProvider: r.VPC.CloudProvider,
-		CloudSpace:    r.VPC.Cloudspace,
-		Region:        r.VPC.Region,
-		CidrIpv4Block: r.VPC.CidrIpv4Block,
+		ID:            r.ID,
+		Name:          r.Name,
+		CloudProvider: r.CloudProvider,
+		CloudSpace:    r.Cloudspace,
+		Region:        r.Region,
+		CidrIpv4Block: r.CidrIpv4Block,
+		Status:        r.Status,
 	}
 }
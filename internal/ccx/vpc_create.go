@@ -2,8 +2,12 @@ package ccx
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type createVpcRequest struct {
@@ -39,5 +43,44 @@ func (svc *VPCsClient) Create(ctx context.Context, vpc VPC) (*VPC, error) {
 
 	newVPC := vpcFromResponse(rs)
 
-	return &newVPC, nil
+	ready, err := svc.awaitReady(ctx, newVPC.ID)
+	if err != nil {
+		// the vpc exists, so it is returned to be stored, and replaced or deleted later
+		return &newVPC, fmt.Errorf("awaiting vpc %s: %w", newVPC.ID, err)
+	}
+
+	return ready, nil
+}
+
+// awaitReady reads the vpc until it is ready, it fails, or ctx is done, e.g. when the create timeout is reached
+func (svc *VPCsClient) awaitReady(ctx context.Context, id string) (*VPC, error) {
+	ticker := time.NewTicker(svc.tick)
+	defer ticker.Stop()
+
+	status := "unknown"
+
+	for {
+		v, err := svc.Read(ctx, id)
+
+		switch {
+		case errors.Is(err, ErrResourceNotFound):
+			// the vpc may not be visible right after creating it
+		case err != nil:
+			return nil, err
+		case v.Ready():
+			return v, nil
+		case v.Failed():
+			return nil, fmt.Errorf("vpc failed with status %s", v.Status)
+		default:
+			status = v.Status
+		}
+
+		tflog.Info(ctx, "waiting for vpc to be ready", map[string]any{"id": id, "status": status})
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("vpc is not ready, the last status was %s: %w", status, ctx.Err())
+		case <-ticker.C:
+		}
+	}
 }
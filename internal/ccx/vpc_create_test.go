@@ -0,0 +1,88 @@
+package ccx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVPCsClient_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []string // returned by each read, an empty string is not found
+		wantStatus string
+		wantErr    string
+	}{
+		{
+			name:       "ready",
+			statuses:   []string{"", "creating", "creating", "ready"},
+			wantStatus: "ready",
+		},
+		{
+			name:       "no status",
+			statuses:   []string{"-"},
+			wantStatus: "",
+		},
+		{
+			name:     "failed",
+			statuses: []string{"creating", "failed"},
+			wantErr:  "awaiting vpc vpc-1: vpc failed with status failed",
+		},
+		{
+			name:     "timeout",
+			statuses: []string{"creating"},
+			wantErr:  "awaiting vpc vpc-1: vpc is not ready, the last status was creating: context deadline exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpcli := NewMockHTTPClient(t)
+
+			httpcli.EXPECT().Do(mock.Anything, http.MethodPost, "/api/vpc/api/v2/vpcs", mock.Anything).
+				Return(fakeHttpResponse(http.StatusOK, `{"vpc": {"id": "vpc-1", "name": "venus", "status": "creating"}}`), nil).Once()
+
+			reads := 0
+
+			httpcli.EXPECT().Get(mock.Anything, "/api/vpc/api/v2/vpcs/vpc-1", mock.Anything).RunAndReturn(func(_ context.Context, _ string, target any) error {
+				// the last status is repeated
+				status := tt.statuses[min(reads, len(tt.statuses)-1)]
+				reads++
+
+				switch status {
+				case "":
+					return ErrResourceNotFound
+				case "-":
+					return json.Unmarshal([]byte(`{"vpc": {"id": "vpc-1", "name": "venus"}}`), target)
+				}
+
+				return json.Unmarshal([]byte(`{"vpc": {"id": "vpc-1", "name": "venus", "status": "`+status+`"}}`), target)
+			})
+
+			svc := &VPCsClient{
+				httpcli: httpcli,
+				tick:    time.Millisecond,
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			got, err := svc.Create(ctx, VPC{Name: "venus"})
+			require.NotNil(t, got)
+			require.Equal(t, "vpc-1", got.ID)
+
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantStatus, got.Status)
+		})
+	}
+}
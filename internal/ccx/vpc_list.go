@@ -0,0 +1,24 @@
+package ccx
+
+import (
+	"context"
+)
+
+type listVpcsResponse struct {
+	VPCs []vpcResponseItem `json:"vpcs"`
+}
+
+func (svc *VPCsClient) List(ctx context.Context) ([]VPC, error) {
+	var rs listVpcsResponse
+
+	if err := svc.httpcli.Get(ctx, "/api/vpc/api/v2/vpcs", &rs); err != nil {
+		return nil, err
+	}
+
+	ls := make([]VPC, 0, len(rs.VPCs))
+	for i := range rs.VPCs {
+		ls = append(ls, vpcFromResponseItem(rs.VPCs[i]))
+	}
+
+	return ls, nil
+}
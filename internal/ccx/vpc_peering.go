@@ -0,0 +1,64 @@
+package ccx
+
+import (
+	"time"
+)
+
+type VPCPeeringsClient struct {
+	httpcli HTTPClient
+	tick    time.Duration // time to wait between vpc peering status checks
+}
+
+// NewVPCPeeringsClient creates a new VPCPeeringsService
+func NewVPCPeeringsClient(httpcli HTTPClient) VPCPeeringsService {
+	return &VPCPeeringsClient{
+		httpcli: httpcli,
+		tick:    time.Second * 10,
+	}
+}
+
+type vpcPeeringResponseItem struct {
+	ID                 string `json:"id"`
+	VpcID              string `json:"vpc_id"`
+	PeerCloudAccountID string `json:"peer_cloud_account_id"`
+	PeerVpcID          string `json:"peer_vpc_id"`
+	PeerCidrIpv4Block  string `json:"peer_cidr_ipv4_block"`
+	PeerRegion         string `json:"peer_region"`
+	Status             string `json:"status"`
+	StatusMessage      string `json:"status_message"`
+	CloudPeeringID     string `json:"cloud_peering_id"`
+	CloudAccountID     string `json:"cloud_account_id"`
+	CloudVpcID         string `json:"cloud_vpc_id"`
+}
+
+type vpcPeeringResponse struct {
+	VPCPeering *vpcPeeringResponseItem `json:"vpc_peering"`
+}
+
+func vpcPeeringFromResponse(r vpcPeeringResponse) VPCPeering {
+	if r.VPCPeering == nil {
+		return VPCPeering{}
+	}
+
+	return vpcPeeringFromResponseItem(*r.VPCPeering)
+}
+
+func vpcPeeringFromResponseItem(r vpcPeeringResponseItem) VPCPeering {
+	return VPCPeering{
+		ID:                 r.ID,
+		VpcID:              r.VpcID,
+		PeerCloudAccountID: r.PeerCloudAccountID,
+		PeerVpcID:          r.PeerVpcID,
+		PeerCidrIpv4Block:  r.PeerCidrIpv4Block,
+		PeerRegion:         r.PeerRegion,
+		Status:             r.Status,
+		StatusMessage:      r.StatusMessage,
+		CloudPeeringID:     r.CloudPeeringID,
+		CloudAccountID:     r.CloudAccountID,
+		CloudVpcID:         r.CloudVpcID,
+	}
+}
+
+func vpcPeeringsPath(vpcID string) string {
+	return "/api/vpc/api/v2/vpcs/" + vpcID + "/peerings"
+}
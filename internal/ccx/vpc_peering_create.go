@@ -0,0 +1,88 @@
+package ccx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type createVpcPeeringRequest struct {
+	PeerCloudAccountID string `json:"peer_cloud_account_id"`
+	PeerVpcID          string `json:"peer_vpc_id"`
+	PeerCidrIpv4Block  string `json:"peer_cidr_ipv4_block"`
+	PeerRegion         string `json:"peer_region,omitempty"`
+}
+
+func (svc *VPCPeeringsClient) Create(ctx context.Context, p VPCPeering) (*VPCPeering, error) {
+	cr := createVpcPeeringRequest{
+		PeerCloudAccountID: p.PeerCloudAccountID,
+		PeerVpcID:          p.PeerVpcID,
+		PeerCidrIpv4Block:  p.PeerCidrIpv4Block,
+		PeerRegion:         p.PeerRegion,
+	}
+
+	res, err := svc.httpcli.Do(ctx, http.MethodPost, vpcPeeringsPath(p.VpcID), cr)
+	if err != nil {
+		return nil, fmt.Errorf("creating vpc peering: %w", err)
+	}
+
+	var rs vpcPeeringResponse
+	if err := DecodeJsonInto(res.Body, &rs); err != nil {
+		return nil, err
+	}
+
+	n := vpcPeeringFromResponse(rs)
+
+	if n.VpcID == "" {
+		n.VpcID = p.VpcID
+	}
+
+	requested, err := svc.awaitRequested(ctx, n)
+	if err != nil {
+		// the peering exists, so it is returned with its last status to be stored, and replaced or deleted later
+		return requested, fmt.Errorf("awaiting vpc peering %s: %w", n.ID, err)
+	}
+
+	return requested, nil
+}
+
+// awaitRequested reads the peering until it is requested in the cloud, it fails, or ctx is done, e.g. when the create timeout is reached,
+// the last read peering is returned on errors too
+func (svc *VPCPeeringsClient) awaitRequested(ctx context.Context, p VPCPeering) (*VPCPeering, error) {
+	ticker := time.NewTicker(svc.tick)
+	defer ticker.Stop()
+
+	for {
+		switch {
+		case p.Failed() && p.StatusMessage != "":
+			return &p, fmt.Errorf("vpc peering failed with status %s: %s", p.Status, p.StatusMessage)
+		case p.Failed():
+			return &p, fmt.Errorf("vpc peering failed with status %s", p.Status)
+		case p.Requested():
+			return &p, nil
+		}
+
+		tflog.Info(ctx, "waiting for vpc peering to be requested", map[string]any{"vpc_id": p.VpcID, "id": p.ID, "status": p.Status})
+
+		select {
+		case <-ctx.Done():
+			return &p, fmt.Errorf("vpc peering is not requested in the cloud, the last status was %s: %w", p.Status, ctx.Err())
+		case <-ticker.C:
+		}
+
+		n, err := svc.Read(ctx, p.VpcID, p.ID)
+
+		switch {
+		case errors.Is(err, ErrResourceNotFound):
+			// the peering may not be visible right after creating it
+		case err != nil:
+			return &p, err
+		default:
+			p = *n
+		}
+	}
+}
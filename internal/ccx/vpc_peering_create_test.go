@@ -0,0 +1,106 @@
+package ccx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVPCPeeringsClient_Create(t *testing.T) {
+	const (
+		pending   = `"status": "pending"`
+		requested = `"status": "pending-acceptance", "cloud_peering_id": "pcx-1", "cloud_account_id": "210987654321", "cloud_vpc_id": "vpc-9f8e7d6c"`
+		active    = `"status": "active", "cloud_account_id": "my-project", "cloud_vpc_id": "ccx-network"`
+		failed    = `"status": "failed", "status_message": "the peer vpc was not found"`
+	)
+
+	tests := []struct {
+		name       string
+		created    string
+		reads      []string // returned by each read, an empty string is not found
+		wantStatus string
+		wantErr    string
+	}{
+		{
+			name:       "requested when created",
+			created:    requested,
+			wantStatus: "pending-acceptance",
+		},
+		{
+			name:       "requested",
+			created:    pending,
+			reads:      []string{"", pending, requested},
+			wantStatus: "pending-acceptance",
+		},
+		{
+			name:       "active without peering id",
+			created:    pending,
+			reads:      []string{active},
+			wantStatus: "active",
+		},
+		{
+			name:       "failed",
+			created:    pending,
+			reads:      []string{pending, failed},
+			wantStatus: "failed",
+			wantErr:    "awaiting vpc peering peering-1: vpc peering failed with status failed: the peer vpc was not found",
+		},
+		{
+			name:       "timeout",
+			created:    pending,
+			reads:      []string{pending},
+			wantStatus: "pending",
+			wantErr:    "awaiting vpc peering peering-1: vpc peering is not requested in the cloud, the last status was pending: context deadline exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpcli := NewMockHTTPClient(t)
+
+			httpcli.EXPECT().Do(mock.Anything, http.MethodPost, "/api/vpc/api/v2/vpcs/vpc-1/peerings", mock.Anything).
+				Return(fakeHttpResponse(http.StatusCreated, `{"vpc_peering": {"id": "peering-1", "vpc_id": "vpc-1", `+tt.created+`}}`), nil).Once()
+
+			reads := 0
+
+			if len(tt.reads) > 0 {
+				httpcli.EXPECT().Get(mock.Anything, "/api/vpc/api/v2/vpcs/vpc-1/peerings/peering-1", mock.Anything).RunAndReturn(func(_ context.Context, _ string, target any) error {
+					// the last read is repeated
+					s := tt.reads[min(reads, len(tt.reads)-1)]
+					reads++
+
+					if s == "" {
+						return ErrResourceNotFound
+					}
+
+					return json.Unmarshal([]byte(`{"vpc_peering": {"id": "peering-1", "vpc_id": "vpc-1", `+s+`}}`), target)
+				})
+			}
+
+			svc := &VPCPeeringsClient{
+				httpcli: httpcli,
+				tick:    time.Millisecond,
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			got, err := svc.Create(ctx, VPCPeering{VpcID: "vpc-1", PeerCloudAccountID: "123456789012", PeerVpcID: "vpc-0a1b2c3d", PeerCidrIpv4Block: "10.20.0.0/16"})
+			require.NotNil(t, got)
+			require.Equal(t, "peering-1", got.ID)
+			require.Equal(t, tt.wantStatus, got.Status)
+
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package ccx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func (svc *VPCPeeringsClient) Delete(ctx context.Context, vpcID, id string) error {
+	_, err := svc.httpcli.Do(ctx, http.MethodDelete, vpcPeeringsPath(vpcID)+"/"+id, nil)
+	if errors.Is(err, ErrResourceNotFound) {
+		tflog.Warn(ctx, "deleting vpc peering: not found", map[string]any{"vpc_id": vpcID, "id": id})
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("deleting vpc peering: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package ccx
+
+import (
+	"context"
+)
+
+func (svc *VPCPeeringsClient) Read(ctx context.Context, vpcID, id string) (*VPCPeering, error) {
+	var rs vpcPeeringResponse
+
+	if err := svc.httpcli.Get(ctx, vpcPeeringsPath(vpcID)+"/"+id, &rs); err != nil {
+		return nil, err
+	}
+
+	p := vpcPeeringFromResponse(rs)
+
+	if p.VpcID == "" {
+		p.VpcID = vpcID
+	}
+
+	return &p, nil
+}
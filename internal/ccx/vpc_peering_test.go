@@ -0,0 +1,80 @@
+package ccx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVPCPeeringsClient(t *testing.T) {
+	httpcli := NewMockHTTPClient(t)
+
+	response := `{"vpc_peering": {
+		"id": "peering-1",
+		"vpc_id": "vpc-1",
+		"peer_cloud_account_id": "123456789012",
+		"peer_vpc_id": "vpc-0a1b2c3d",
+		"peer_cidr_ipv4_block": "10.20.0.0/16",
+		"peer_region": "eu-north-1",
+		"status": "pending-acceptance",
+		"cloud_peering_id": "pcx-0a1b2c3d",
+		"cloud_account_id": "210987654321",
+		"cloud_vpc_id": "vpc-9f8e7d6c"
+	}}`
+
+	want := &VPCPeering{
+		ID:                 "peering-1",
+		VpcID:              "vpc-1",
+		PeerCloudAccountID: "123456789012",
+		PeerVpcID:          "vpc-0a1b2c3d",
+		PeerCidrIpv4Block:  "10.20.0.0/16",
+		PeerRegion:         "eu-north-1",
+		Status:             "pending-acceptance",
+		CloudPeeringID:     "pcx-0a1b2c3d",
+		CloudAccountID:     "210987654321",
+		CloudVpcID:         "vpc-9f8e7d6c",
+	}
+
+	httpcli.EXPECT().Do(mock.Anything, http.MethodPost, "/api/vpc/api/v2/vpcs/vpc-1/peerings", createVpcPeeringRequest{
+		PeerCloudAccountID: "123456789012",
+		PeerVpcID:          "vpc-0a1b2c3d",
+		PeerCidrIpv4Block:  "10.20.0.0/16",
+	}).Return(fakeHttpResponse(http.StatusCreated, response), nil).Once()
+	httpcli.EXPECT().Get(mock.Anything, "/api/vpc/api/v2/vpcs/vpc-1/peerings/peering-1", mock.Anything).RunAndReturn(func(_ context.Context, _ string, target any) error {
+		return json.Unmarshal([]byte(response), target)
+	}).Once()
+	httpcli.EXPECT().Do(mock.Anything, http.MethodDelete, "/api/vpc/api/v2/vpcs/vpc-1/peerings/peering-1", nil).
+		Return(fakeHttpResponse(http.StatusOK, ""), nil).Once()
+	httpcli.EXPECT().Do(mock.Anything, http.MethodDelete, "/api/vpc/api/v2/vpcs/vpc-1/peerings/peering-2", nil).
+		Return(nil, ErrResourceNotFound).Once()
+
+	svc := &VPCPeeringsClient{
+		httpcli: httpcli,
+		tick:    time.Millisecond,
+	}
+
+	ctx := context.Background()
+
+	got, err := svc.Create(ctx, VPCPeering{
+		VpcID:              "vpc-1",
+		PeerCloudAccountID: "123456789012",
+		PeerVpcID:          "vpc-0a1b2c3d",
+		PeerCidrIpv4Block:  "10.20.0.0/16",
+	})
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	got, err = svc.Read(ctx, "vpc-1", "peering-1")
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	require.NoError(t, svc.Delete(ctx, "vpc-1", "peering-1"))
+
+	// already deleted
+	require.NoError(t, svc.Delete(ctx, "vpc-1", "peering-2"))
+}
@@ -2,8 +2,23 @@ package ccx
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 )
 
-func (svc *VPCsClient) Update(_ context.Context, _ VPC) (*VPC, error) {
-	return nil, ErrUpdateNotSupported
+type updateVpcRequest struct {
+	Name string `json:"name"`
+}
+
+// Update changes the mutable fields of the vpc, i.e. the name, the others require replacing the vpc
+func (svc *VPCsClient) Update(ctx context.Context, vpc VPC) (*VPC, error) {
+	ur := updateVpcRequest{
+		Name: vpc.Name,
+	}
+
+	if _, err := svc.httpcli.Do(ctx, http.MethodPatch, "/api/vpc/api/v2/vpcs/"+vpc.ID, ur); err != nil {
+		return nil, fmt.Errorf("updating vpc: %w", err)
+	}
+
+	return svc.Read(ctx, vpc.ID)
 }
@@ -0,0 +1,43 @@
+package ccx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVPCsClient_Update(t *testing.T) {
+	httpcli := NewMockHTTPClient(t)
+
+	httpcli.EXPECT().Do(mock.Anything, http.MethodPatch, "/api/vpc/api/v2/vpcs/vpc-1", updateVpcRequest{Name: "mars"}).
+		Return(fakeHttpResponse(http.StatusOK, ""), nil).Once()
+	httpcli.EXPECT().Get(mock.Anything, "/api/vpc/api/v2/vpcs/vpc-1", mock.Anything).RunAndReturn(func(_ context.Context, _ string, target any) error {
+		return json.Unmarshal([]byte(`{"vpc": {"id": "vpc-1", "name": "mars", "cloudspace": "default", "cloud": "aws", "region": "eu-north-1", "cidr_ipv4_block": "10.10.0.0/16"}}`), target)
+	}).Once()
+
+	svc := &VPCsClient{
+		httpcli: httpcli,
+	}
+
+	got, err := svc.Update(context.Background(), VPC{
+		ID:            "vpc-1",
+		Name:          "mars",
+		CloudSpace:    "default",
+		CloudProvider: "aws",
+		Region:        "eu-north-1",
+		CidrIpv4Block: "10.10.0.0/16",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &VPC{
+		ID:            "vpc-1",
+		Name:          "mars",
+		CloudSpace:    "default",
+		CloudProvider: "aws",
+		Region:        "eu-north-1",
+		CidrIpv4Block: "10.10.0.0/16",
+	}, got)
+}
@@ -0,0 +1,430 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Exporter generates terraform configuration for resources which already exist in CCX
+type Exporter struct {
+	datastores      ccx.DatastoresService
+	vpcs            ccx.VPCsService
+	parameterGroups ccx.ParameterGroupsService
+}
+
+// NewExporter creates a new Exporter
+func NewExporter(datastores ccx.DatastoresService, vpcs ccx.VPCsService, parameterGroups ccx.ParameterGroupsService) *Exporter {
+	return &Exporter{
+		datastores:      datastores,
+		vpcs:            vpcs,
+		parameterGroups: parameterGroups,
+	}
+}
+
+// Export lists all resources and returns the generated files, file name -> content
+// references between resources (vpc, parameter group, datastores of an ip set) are written as terraform references
+// every resource gets an import block, so that `terraform plan` picks them up
+func (e *Exporter) Export(ctx context.Context) (map[string][]byte, error) {
+	vpcs, err := e.vpcs.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing vpcs: %w", err)
+	}
+
+	groups, err := e.parameterGroups.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing parameter groups: %w", err)
+	}
+
+	stores, err := e.datastores.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing datastores: %w", err)
+	}
+
+	// list does not include all the details, e.g. firewall rules
+	for i := range stores {
+		c, err := e.datastores.Read(ctx, stores[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("reading datastore %q: %w", stores[i].ID, err)
+		}
+
+		stores[i] = *c
+	}
+
+	slices.SortStableFunc(vpcs, func(a, b ccx.VPC) int { return strings.Compare(a.Name, b.Name) })
+	slices.SortStableFunc(groups, func(a, b ccx.ParameterGroup) int { return strings.Compare(a.Name, b.Name) })
+	slices.SortStableFunc(stores, func(a, b ccx.Datastore) int { return strings.Compare(a.Name, b.Name) })
+
+	var (
+		names   = make(resourceNames)
+		refs    = make(map[string]hcl.Traversal) // resource id -> traversal of the resource
+		imports = hclwrite.NewEmptyFile()
+		files   = make(map[string][]byte)
+	)
+
+	if len(vpcs) != 0 {
+		f := hclwrite.NewEmptyFile()
+
+		for _, v := range vpcs {
+			addr := hcl.Traversal{hcl.TraverseRoot{Name: "ccx_vpc"}, hcl.TraverseAttr{Name: names.next("ccx_vpc", v.Name)}}
+			refs[v.ID] = attr(addr, "id")
+
+			writeVPC(f.Body(), addr, v)
+			writeImport(imports.Body(), addr, v.ID)
+		}
+
+		files["vpcs.tf"] = f.Bytes()
+	}
+
+	if len(groups) != 0 {
+		f := hclwrite.NewEmptyFile()
+
+		for _, p := range groups {
+			addr := hcl.Traversal{hcl.TraverseRoot{Name: "ccx_parameter_group"}, hcl.TraverseAttr{Name: names.next("ccx_parameter_group", p.Name)}}
+			refs[p.ID] = attr(addr, "id")
+
+			writeParameterGroup(f.Body(), addr, p)
+			writeImport(imports.Body(), addr, p.ID)
+		}
+
+		files["parameter_groups.tf"] = f.Bytes()
+	}
+
+	if len(stores) != 0 {
+		f := hclwrite.NewEmptyFile()
+
+		for _, c := range stores {
+			addr := hcl.Traversal{hcl.TraverseRoot{Name: "ccx_datastore"}, hcl.TraverseAttr{Name: names.next("ccx_datastore", c.Name)}}
+			refs[c.ID] = attr(addr, "id")
+
+			writeDatastore(f.Body(), addr, c, refs)
+			writeImport(imports.Body(), addr, c.ID)
+		}
+
+		files["datastores.tf"] = f.Bytes()
+	}
+
+	if sets := ipSets(stores); len(sets) != 0 {
+		f := hclwrite.NewEmptyFile()
+
+		for _, s := range sets {
+			addr := hcl.Traversal{hcl.TraverseRoot{Name: "ccx_ip_set"}, hcl.TraverseAttr{Name: names.next("ccx_ip_set", s.name)}}
+
+			writeIPSet(f.Body(), addr, s, refs)
+			writeImport(imports.Body(), addr, s.name)
+		}
+
+		files["ip_sets.tf"] = f.Bytes()
+	}
+
+	if len(files) != 0 {
+		files["imports.tf"] = imports.Bytes()
+	}
+
+	return files, nil
+}
+
+func attr(t hcl.Traversal, name string) hcl.Traversal {
+	return append(slices.Clone(t), hcl.TraverseAttr{Name: name})
+}
+
+func newResourceBlock(body *hclwrite.Body, addr hcl.Traversal) *hclwrite.Body {
+	if len(body.Blocks()) != 0 {
+		body.AppendNewline()
+	}
+
+	b := body.AppendNewBlock("resource", []string{addr.RootName(), addr[1].(hcl.TraverseAttr).Name})
+
+	return b.Body()
+}
+
+func writeImport(body *hclwrite.Body, addr hcl.Traversal, id string) {
+	if len(body.Blocks()) != 0 {
+		body.AppendNewline()
+	}
+
+	b := body.AppendNewBlock("import", nil).Body()
+	b.SetAttributeTraversal("to", addr)
+	b.SetAttributeValue("id", cty.StringVal(id))
+}
+
+// setReferences sets the attribute to a list of references, or ids for the resources which are not exported
+func setReferences(body *hclwrite.Body, name string, ids []string, refs map[string]hcl.Traversal) {
+	tokens := hclwrite.Tokens{{Type: hclsyntax.TokenOBrack, Bytes: []byte("[")}}
+
+	for i, id := range ids {
+		if i != 0 {
+			tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenComma, Bytes: []byte(",")})
+		}
+
+		if t, ok := refs[id]; ok {
+			tokens = append(tokens, hclwrite.TokensForTraversal(t)...)
+		} else {
+			tokens = append(tokens, hclwrite.TokensForValue(cty.StringVal(id))...)
+		}
+	}
+
+	tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCBrack, Bytes: []byte("]")})
+
+	body.SetAttributeRaw(name, tokens)
+}
+
+// setReference sets the attribute to a reference, if the id belongs to an exported resource, otherwise to the id itself
+func setReference(body *hclwrite.Body, name, id string, refs map[string]hcl.Traversal) {
+	if t, ok := refs[id]; ok {
+		body.SetAttributeTraversal(name, t)
+		return
+	}
+
+	body.SetAttributeValue(name, cty.StringVal(id))
+}
+
+func stringList(ls []string) cty.Value {
+	if len(ls) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+
+	values := make([]cty.Value, 0, len(ls))
+	for _, s := range ls {
+		values = append(values, cty.StringVal(s))
+	}
+
+	return cty.ListVal(values)
+}
+
+func writeVPC(body *hclwrite.Body, addr hcl.Traversal, v ccx.VPC) {
+	b := newResourceBlock(body, addr)
+
+	b.SetAttributeValue("name", cty.StringVal(v.Name))
+	b.SetAttributeValue("cloud_provider", cty.StringVal(v.CloudProvider))
+
+	if v.Region != "" {
+		b.SetAttributeValue("cloud_region", cty.StringVal(v.Region))
+	}
+
+	if v.CidrIpv4Block != "" {
+		b.SetAttributeValue("ipv4_cidr", cty.StringVal(v.CidrIpv4Block))
+	}
+}
+
+func writeParameterGroup(body *hclwrite.Body, addr hcl.Traversal, p ccx.ParameterGroup) {
+	b := newResourceBlock(body, addr)
+
+	b.SetAttributeValue("name", cty.StringVal(p.Name))
+	b.SetAttributeValue("database_vendor", cty.StringVal(p.DatabaseVendor))
+	b.SetAttributeValue("database_version", cty.StringVal(p.DatabaseVersion))
+	b.SetAttributeValue("database_type", cty.StringVal(p.DatabaseType))
+
+	if p.Description != "" {
+		b.SetAttributeValue("description", cty.StringVal(p.Description))
+	}
+
+	parameters := make(map[string]cty.Value, len(p.DbParameters))
+	for k, v := range p.DbParameters {
+		parameters[k] = cty.StringVal(v)
+	}
+
+	if len(parameters) == 0 {
+		b.SetAttributeValue("parameters", cty.MapValEmpty(cty.String))
+	} else {
+		b.SetAttributeValue("parameters", cty.MapVal(parameters))
+	}
+}
+
+func writeDatastore(body *hclwrite.Body, addr hcl.Traversal, c ccx.Datastore, refs map[string]hcl.Traversal) {
+	b := newResourceBlock(body, addr)
+
+	b.SetAttributeValue("name", cty.StringVal(c.Name))
+	b.SetAttributeValue("size", cty.NumberIntVal(c.Size))
+	b.SetAttributeValue("db_vendor", cty.StringVal(c.DBVendor))
+	b.SetAttributeValue("db_version", cty.StringVal(c.DBVersion))
+	b.SetAttributeValue("type", cty.StringVal(strings.ToLower(c.Type)))
+
+	if tags := c.UserTags(); len(tags) != 0 {
+		b.SetAttributeValue("tags", stringList(tags))
+	}
+
+	b.SetAttributeValue("cloud_provider", cty.StringVal(c.CloudProvider))
+	b.SetAttributeValue("cloud_region", cty.StringVal(c.CloudRegion))
+	b.SetAttributeValue("instance_size", cty.StringVal(c.InstanceSize))
+
+	if c.VolumeType != "" {
+		b.SetAttributeValue("volume_type", cty.StringVal(c.VolumeType))
+	}
+
+	if c.VolumeSize != 0 {
+		b.SetAttributeValue("volume_size", cty.NumberUIntVal(c.VolumeSize))
+	}
+
+	if c.VolumeIOPS != 0 {
+		b.SetAttributeValue("volume_iops", cty.NumberUIntVal(c.VolumeIOPS))
+	}
+
+	if c.VolumeThroughput != 0 {
+		b.SetAttributeValue("volume_throughput", cty.NumberUIntVal(c.VolumeThroughput))
+	}
+
+	if c.HAEnabled {
+		b.SetAttributeValue("network_ha_enabled", cty.True)
+	}
+
+	if c.VpcUUID != "" {
+		setReference(b, "network_vpc_uuid", c.VpcUUID, refs)
+	}
+
+	if c.ParameterGroupID != "" {
+		setReference(b, "parameter_group", c.ParameterGroupID, refs)
+	}
+
+	b.SetAttributeValue("notifications_enabled", cty.BoolVal(c.Notifications.Enabled))
+
+	if len(c.Notifications.Emails) != 0 {
+		b.SetAttributeValue("notifications_emails", stringList(c.Notifications.Emails))
+	}
+
+	if m := c.MaintenanceSettings; m != nil {
+		b.SetAttributeValue("maintenance_day_of_week", cty.NumberIntVal(int64(m.DayOfWeek)))
+		b.SetAttributeValue("maintenance_start_hour", cty.NumberIntVal(int64(m.StartHour)))
+		b.SetAttributeValue("maintenance_end_hour", cty.NumberIntVal(int64(m.EndHour)))
+	}
+
+	if a := c.StorageAutoscale; a != nil {
+		b.AppendNewline()
+
+		as := b.AppendNewBlock("storage_autoscale", nil).Body()
+		as.SetAttributeValue("enabled", cty.BoolVal(a.Enabled))
+
+		if a.MaxSize != 0 {
+			as.SetAttributeValue("max_size", cty.NumberUIntVal(a.MaxSize))
+		}
+
+		if a.ThresholdPct != 0 {
+			as.SetAttributeValue("threshold_pct", cty.NumberIntVal(int64(a.ThresholdPct)))
+		}
+	}
+
+	var sets []string
+
+	for _, f := range c.FirewallRules {
+		if name, ok := f.IPSet(); ok {
+			sets = append(sets, name)
+			continue
+		}
+
+		b.AppendNewline()
+
+		fw := b.AppendNewBlock("firewall", nil).Body()
+		fw.SetAttributeValue("source", cty.StringVal(f.Source))
+		fw.SetAttributeValue("description", cty.StringVal(f.Description))
+
+		if len(f.Ports) != 0 {
+			fw.SetAttributeValue("ports", stringList(f.Ports))
+		}
+	}
+
+	// the rules of an ip set are referenced by the name of the set, a reference to the resource would be a cycle,
+	// as the set references the datastores
+	slices.Sort(sets)
+
+	for _, name := range slices.Compact(sets) {
+		b.AppendNewline()
+
+		fw := b.AppendNewBlock("firewall", nil).Body()
+		fw.SetAttributeValue("ip_set", cty.StringVal(name))
+	}
+}
+
+// ipSet is a ccx_ip_set, as found in the firewall rules of the datastores
+type ipSet struct {
+	name       string
+	cidrs      []string
+	ports      []string
+	datastores []string
+}
+
+// ipSets returns the ip sets of the datastores, sorted by name
+func ipSets(stores []ccx.Datastore) []ipSet {
+	byName := make(map[string]*ipSet)
+
+	for _, c := range stores {
+		for _, f := range c.FirewallRules {
+			name, ok := f.IPSet()
+			if !ok || f.Source == "" {
+				continue
+			}
+
+			s, ok := byName[name]
+			if !ok {
+				s = &ipSet{name: name, ports: f.Ports}
+				byName[name] = s
+			}
+
+			if !slices.Contains(s.cidrs, f.Source) {
+				s.cidrs = append(s.cidrs, f.Source)
+			}
+
+			if !slices.Contains(s.datastores, c.ID) {
+				s.datastores = append(s.datastores, c.ID)
+			}
+		}
+	}
+
+	ls := make([]ipSet, 0, len(byName))
+	for _, s := range byName {
+		slices.Sort(s.cidrs)
+		ls = append(ls, *s)
+	}
+
+	slices.SortFunc(ls, func(a, b ipSet) int { return strings.Compare(a.name, b.name) })
+
+	return ls
+}
+
+func writeIPSet(body *hclwrite.Body, addr hcl.Traversal, s ipSet, refs map[string]hcl.Traversal) {
+	b := newResourceBlock(body, addr)
+
+	b.SetAttributeValue("name", cty.StringVal(s.name))
+	b.SetAttributeValue("cidrs", stringList(s.cidrs))
+
+	if len(s.ports) != 0 {
+		b.SetAttributeValue("ports", stringList(s.ports))
+	}
+
+	setReferences(b, "datastore_ids", s.datastores, refs)
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// resourceNames makes unique terraform resource names, per resource type, by the addresses already used
+type resourceNames map[string]bool
+
+func (r resourceNames) next(resourceType, name string) string {
+	s := invalidNameChars.ReplaceAllString(strings.ToLower(name), "_")
+	s = strings.Trim(s, "_-")
+
+	if s == "" {
+		s = strings.TrimPrefix(resourceType, "ccx_")
+	} else if c := s[0]; c >= '0' && c <= '9' {
+		s = "_" + s
+	}
+
+	name = s
+
+	// a suffixed name may be used already, e.g. by a datastore named "luna_2"
+	for n := 2; r[resourceType+"."+name]; n++ {
+		name = s + "_" + strconv.Itoa(n)
+	}
+
+	r[resourceType+"."+name] = true
+
+	return name
+}
@@ -0,0 +1,213 @@
+package export
+
+import (
+	"context"
+	"testing"
+
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExporter_Export(t *testing.T) {
+	datastores := ccx.NewMockDatastoresService(t)
+	vpcs := ccx.NewMockVPCsService(t)
+	groups := ccx.NewMockParameterGroupsService(t)
+
+	vpcs.EXPECT().List(mock.Anything).Return([]ccx.VPC{
+		{ID: "vpc-1", Name: "Venus VPC", CloudProvider: "aws", Region: "eu-north-1", CidrIpv4Block: "10.10.0.0/16"},
+	}, nil)
+
+	groups.EXPECT().List(mock.Anything).Return([]ccx.ParameterGroup{
+		{
+			ID:              "group-1",
+			Name:            "asteroid",
+			DatabaseVendor:  "percona",
+			DatabaseVersion: "8",
+			DatabaseType:    "replication",
+			DbParameters:    map[string]string{"table_open_cache": "8000", "sql_mode": "STRICT_TRANS_TABLES"},
+		},
+	}, nil)
+
+	datastores.EXPECT().List(mock.Anything).Return([]ccx.Datastore{
+		{ID: "datastore-2", Name: "sol"},
+		{ID: "datastore-1", Name: "luna"},
+	}, nil)
+
+	datastores.EXPECT().Read(mock.Anything, "datastore-1").Return(&ccx.Datastore{
+		ID:               "datastore-1",
+		Name:             "luna",
+		Size:             3,
+		DBVendor:         "percona",
+		DBVersion:        "8",
+		Type:             "Replication",
+		Tags:             []string{"prod", "percona", "8", "replication", "aws", "eu-north-1"},
+		CloudProvider:    "aws",
+		CloudRegion:      "eu-north-1",
+		InstanceSize:     "m5.large",
+		VolumeType:       "gp2",
+		VolumeSize:       80,
+		VpcUUID:          "vpc-1",
+		ParameterGroupID: "group-1",
+		FirewallRules: []ccx.FirewallRule{
+			{Source: "1.2.3.4/32", Description: "office", Ports: []string{"mysql"}},
+			{Source: "10.8.0.0/16", Description: "ccx_ip_set:vpn"},
+		},
+		Notifications: ccx.Notifications{Enabled: true, Emails: []string{"user@getccx.com"}},
+		MaintenanceSettings: &ccx.MaintenanceSettings{
+			DayOfWeek: 1,
+			StartHour: 0,
+			EndHour:   2,
+		},
+	}, nil)
+
+	datastores.EXPECT().Read(mock.Anything, "datastore-2").Return(&ccx.Datastore{
+		ID:               "datastore-2",
+		Name:             "sol",
+		Size:             1,
+		DBVendor:         "postgres",
+		DBVersion:        "16",
+		Type:             "postgres_streaming",
+		CloudProvider:    "aws",
+		CloudRegion:      "eu-north-1",
+		InstanceSize:     "m5.large",
+		VolumeType:       "gp2",
+		VolumeSize:       80,
+		VpcUUID:          "vpc-unknown",
+		ParameterGroupID: "",
+		FirewallRules: []ccx.FirewallRule{
+			{Source: "10.9.0.0/16", Description: "ccx_ip_set:vpn"},
+			{Source: "10.8.0.0/16", Description: "ccx_ip_set:vpn"},
+		},
+	}, nil)
+
+	e := NewExporter(datastores, vpcs, groups)
+
+	files, err := e.Export(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, `resource "ccx_vpc" "venus_vpc" {
+  name           = "Venus VPC"
+  cloud_provider = "aws"
+  cloud_region   = "eu-north-1"
+  ipv4_cidr      = "10.10.0.0/16"
+}
+`, string(files["vpcs.tf"]))
+
+	assert.Equal(t, `resource "ccx_parameter_group" "asteroid" {
+  name             = "asteroid"
+  database_vendor  = "percona"
+  database_version = "8"
+  database_type    = "replication"
+  parameters = {
+    sql_mode         = "STRICT_TRANS_TABLES"
+    table_open_cache = "8000"
+  }
+}
+`, string(files["parameter_groups.tf"]))
+
+	assert.Equal(t, `resource "ccx_datastore" "luna" {
+  name                    = "luna"
+  size                    = 3
+  db_vendor               = "percona"
+  db_version              = "8"
+  type                    = "replication"
+  tags                    = ["prod"]
+  cloud_provider          = "aws"
+  cloud_region            = "eu-north-1"
+  instance_size           = "m5.large"
+  volume_type             = "gp2"
+  volume_size             = 80
+  network_vpc_uuid        = ccx_vpc.venus_vpc.id
+  parameter_group         = ccx_parameter_group.asteroid.id
+  notifications_enabled   = true
+  notifications_emails    = ["user@getccx.com"]
+  maintenance_day_of_week = 1
+  maintenance_start_hour  = 0
+  maintenance_end_hour    = 2
+
+  firewall {
+    source      = "1.2.3.4/32"
+    description = "office"
+    ports       = ["mysql"]
+  }
+
+  firewall {
+    ip_set = "vpn"
+  }
+}
+
+resource "ccx_datastore" "sol" {
+  name                  = "sol"
+  size                  = 1
+  db_vendor             = "postgres"
+  db_version            = "16"
+  type                  = "postgres_streaming"
+  cloud_provider        = "aws"
+  cloud_region          = "eu-north-1"
+  instance_size         = "m5.large"
+  volume_type           = "gp2"
+  volume_size           = 80
+  network_vpc_uuid      = "vpc-unknown"
+  notifications_enabled = false
+
+  firewall {
+    ip_set = "vpn"
+  }
+}
+`, string(files["datastores.tf"]))
+
+	assert.Equal(t, `resource "ccx_ip_set" "vpn" {
+  name          = "vpn"
+  cidrs         = ["10.8.0.0/16", "10.9.0.0/16"]
+  datastore_ids = [ccx_datastore.luna.id, ccx_datastore.sol.id]
+}
+`, string(files["ip_sets.tf"]))
+
+	assert.Equal(t, `import {
+  to = ccx_vpc.venus_vpc
+  id = "vpc-1"
+}
+
+import {
+  to = ccx_parameter_group.asteroid
+  id = "group-1"
+}
+
+import {
+  to = ccx_datastore.luna
+  id = "datastore-1"
+}
+
+import {
+  to = ccx_datastore.sol
+  id = "datastore-2"
+}
+
+import {
+  to = ccx_ip_set.vpn
+  id = "vpn"
+}
+`, string(files["imports.tf"]))
+}
+
+func Test_resourceNames_next(t *testing.T) {
+	names := make(resourceNames)
+
+	assert.Equal(t, "luna", names.next("ccx_datastore", "luna"))
+	assert.Equal(t, "luna_2", names.next("ccx_datastore", "Luna"))
+	assert.Equal(t, "luna", names.next("ccx_vpc", "luna"))
+	assert.Equal(t, "my_db-1", names.next("ccx_datastore", "My DB-1"))
+	assert.Equal(t, "_1st", names.next("ccx_datastore", "1st"))
+	assert.Equal(t, "datastore", names.next("ccx_datastore", "!!!"))
+
+	// a suffixed name is not used again
+	names = make(resourceNames)
+
+	assert.Equal(t, "a_2", names.next("ccx_datastore", "a_2"))
+	assert.Equal(t, "a", names.next("ccx_datastore", "a"))
+	assert.Equal(t, "a_3", names.next("ccx_datastore", "a"))
+	assert.Equal(t, "a_4", names.next("ccx_datastore", "a"))
+	assert.Equal(t, "a_2_2", names.next("ccx_datastore", "a 2"))
+}
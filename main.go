@@ -2,23 +2,55 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"log"
+	"os"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 	"github.com/severalnines/terraform-provider-ccx/resources"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(context.Background(), os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "export: %s\n", err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{
-		Debug:        debug,
-		ProviderAddr: "registry.terraform.io/severalnines/ccx",
-		ProviderFunc: resources.Provider,
+	ctx := context.Background()
+
+	// the SDK provider serves the existing resources, the framework provider serves what the SDK cannot, e.g. ephemeral resources
+	providers := []func() tfprotov5.ProviderServer{
+		resources.Provider().GRPCProvider,
+		providerserver.NewProtocol5(resources.FrameworkProvider()),
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, providers...)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	plugin.Serve(opts)
+	var opts []tf5server.ServeOpt
+
+	if debug {
+		opts = append(opts, tf5server.WithManagedDebug())
+	}
+
+	err = tf5server.Serve("registry.terraform.io/severalnines/ccx", muxServer.ProviderServer, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,117 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+)
+
+type storageAutoscale struct{}
+
+func (s storageAutoscale) Schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Enable or disable storage autoscaling.",
+			},
+			"max_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum volume size in GB, which autoscaling may grow the volume to.",
+			},
+			"threshold_pct": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Percentage of used storage, 1-99, at which the volume is grown.",
+			},
+		},
+	}
+}
+
+func getStorageAutoscale(d *schema.ResourceData) *ccx.StorageAutoscale {
+	raw, ok := d.Get("storage_autoscale").([]any)
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+
+	m, ok := raw[0].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	var a ccx.StorageAutoscale
+
+	if v, ok := m["enabled"].(bool); ok {
+		a.Enabled = v
+	}
+
+	if v, ok := m["max_size"].(int); ok {
+		a.MaxSize = uint64(v)
+	}
+
+	if v, ok := m["threshold_pct"].(int); ok {
+		a.ThresholdPct = v
+	}
+
+	return &a
+}
+
+func setStorageAutoscale(d *schema.ResourceData, a *ccx.StorageAutoscale) error {
+	if a == nil {
+		return d.Set("storage_autoscale", []any{})
+	}
+
+	return d.Set("storage_autoscale", []any{
+		map[string]any{
+			"enabled":       a.Enabled,
+			"max_size":      int(a.MaxSize),
+			"threshold_pct": a.ThresholdPct,
+		},
+	})
+}
+
+func validateStorageAutoscale(vendor string, a *ccx.StorageAutoscale, volumeSize uint64) error {
+	if a == nil || !a.Enabled {
+		return nil
+	}
+
+	if vendor == "redis" || vendor == "cache22" || vendor == "valkey" {
+		return fmt.Errorf("storage_autoscale is not supported for vendor %q", vendor)
+	}
+
+	if a.ThresholdPct != 0 && (a.ThresholdPct < 1 || a.ThresholdPct > 99) {
+		return fmt.Errorf("storage_autoscale.threshold_pct must be between 1 and 99: %d", a.ThresholdPct)
+	}
+
+	if a.MaxSize != 0 && a.MaxSize < volumeSize {
+		return fmt.Errorf("storage_autoscale.max_size must not be less than volume_size: %d < %d", a.MaxSize, volumeSize)
+	}
+
+	return nil
+}
+
+// volumeSizeSuppressor treats volume_size as a floor when storage autoscaling is enabled
+// CCX grows the volume on its own then, which should not be reported as a diff
+func volumeSizeSuppressor(_, oldValue, newValue string, d *schema.ResourceData) bool {
+	if a := getStorageAutoscale(d); a == nil || !a.Enabled {
+		return false
+	}
+
+	o, err := strconv.ParseUint(oldValue, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	n, err := strconv.ParseUint(newValue, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return n <= o
+}
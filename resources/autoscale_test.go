@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateStorageAutoscale(t *testing.T) {
+	tests := []struct {
+		name       string
+		vendor     string
+		a          *ccx.StorageAutoscale
+		volumeSize uint64
+		wantErr    bool
+	}{
+		{
+			name:   "not set",
+			vendor: "postgres",
+		},
+		{
+			name:       "disabled",
+			vendor:     "redis",
+			a:          &ccx.StorageAutoscale{Enabled: false, ThresholdPct: 200},
+			volumeSize: 80,
+		},
+		{
+			name:       "valid",
+			vendor:     "postgres",
+			a:          &ccx.StorageAutoscale{Enabled: true, MaxSize: 200, ThresholdPct: 80},
+			volumeSize: 80,
+		},
+		{
+			name:       "server defaults",
+			vendor:     "postgres",
+			a:          &ccx.StorageAutoscale{Enabled: true},
+			volumeSize: 80,
+		},
+		{
+			name:       "not supported for vendor",
+			vendor:     "valkey",
+			a:          &ccx.StorageAutoscale{Enabled: true},
+			volumeSize: 0,
+			wantErr:    true,
+		},
+		{
+			name:       "threshold > 99",
+			vendor:     "postgres",
+			a:          &ccx.StorageAutoscale{Enabled: true, ThresholdPct: 100},
+			volumeSize: 80,
+			wantErr:    true,
+		},
+		{
+			name:       "max_size < volume_size",
+			vendor:     "postgres",
+			a:          &ccx.StorageAutoscale{Enabled: true, MaxSize: 40},
+			volumeSize: 80,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStorageAutoscale(tt.vendor, tt.a, tt.volumeSize)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
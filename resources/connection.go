@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+)
+
+type connection struct{}
+
+func (c connection) Schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"primary_jdbc_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JDBC URL to the primary host(s). Empty for vendors without a JDBC driver.",
+			},
+			"replica_jdbc_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JDBC URL to the replica host(s). Empty for vendors without a JDBC driver.",
+			},
+			"primary_conninfo": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "libpq keyword/value connection string to the primary host(s). PostgreSQL only.",
+			},
+			"replica_conninfo": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "libpq keyword/value connection string to the replica host(s). PostgreSQL only.",
+			},
+			"multi_host_dsn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "DSN listing every host, primary first, for client-side failover. MySQL, MariaDB and PostgreSQL only.",
+			},
+			"multi_host_jdbc_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JDBC URL listing every host, primary first, for client-side failover. MySQL, MariaDB and PostgreSQL only.",
+			},
+			"multi_host_conninfo": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "libpq keyword/value connection string listing every host, primary first, for client-side failover. PostgreSQL only.",
+			},
+		},
+	}
+}
+
+func setConnection(d *schema.ResourceData, c ccx.Connection) error {
+	return d.Set("connection_strings", []any{
+		map[string]any{
+			"primary_jdbc_url":    c.PrimaryJdbcURL,
+			"replica_jdbc_url":    c.ReplicaJdbcURL,
+			"primary_conninfo":    c.PrimaryConninfo,
+			"replica_conninfo":    c.ReplicaConninfo,
+			"multi_host_dsn":      c.MultiHostDsn,
+			"multi_host_jdbc_url": c.MultiHostJdbcURL,
+			"multi_host_conninfo": c.MultiHostConninfo,
+		},
+	})
+}
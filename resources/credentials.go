@@ -0,0 +1,96 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+)
+
+// DatastoreCredentials is an ephemeral resource with the credentials of the default user of a datastore.
+// Unlike the attributes of ccx_datastore, they are never persisted to plan or state.
+type DatastoreCredentials struct {
+	svc ccx.DatastoresService
+}
+
+type datastoreCredentialsModel struct {
+	DatastoreID types.String `tfsdk:"datastore_id"`
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	DbName      types.String `tfsdk:"dbname"`
+	PrimaryDsn  types.String `tfsdk:"primary_dsn"`
+	ReplicaDsn  types.String `tfsdk:"replica_dsn"`
+}
+
+func (r *DatastoreCredentials) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_datastore_credentials"
+}
+
+func (r *DatastoreCredentials) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Credentials of the default user of a datastore. Being ephemeral, the password and the DSNs are not stored in the Terraform state.",
+		Attributes: map[string]schema.Attribute{
+			"datastore_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the datastore.",
+			},
+			"username": schema.StringAttribute{
+				Computed:    true,
+				Description: "Username of the default user.",
+			},
+			"password": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Password of the default user.",
+			},
+			"dbname": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the default database.",
+			},
+			"primary_dsn": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "DSN to the primary host(s), including the password.",
+			},
+			"replica_dsn": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "DSN to the replica host(s), including the password.",
+			},
+		},
+	}
+}
+
+func (r *DatastoreCredentials) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var m datastoreCredentialsModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.svc == nil {
+		resp.Diagnostics.AddError("provider not configured", "the provider must be configured before datastore credentials can be read")
+		return
+	}
+
+	c, err := r.svc.Read(ctx, m.DatastoreID.ValueString())
+	if errors.Is(err, ccx.ErrResourceNotFound) {
+		resp.Diagnostics.AddError("datastore not found", "datastore "+m.DatastoreID.ValueString()+" was not found")
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("failed to read datastore", err.Error())
+		return
+	}
+
+	m.Username = types.StringValue(c.Username)
+	m.Password = types.StringValue(c.Password)
+	m.DbName = types.StringValue(c.DbName)
+	m.PrimaryDsn = types.StringValue(c.PrimaryDsn)
+	m.ReplicaDsn = types.StringValue(c.ReplicaDsn)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &m)...)
+}
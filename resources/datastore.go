@@ -7,6 +7,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
@@ -57,8 +58,7 @@ func (r *Datastore) Schema() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Computed:         true,
-				Description:      "Version of the database system. Refer to the CCX instance to find versions available for each vendor.",
-				ForceNew:         true,
+				Description:      "Version of the database system. Refer to the CCX instance to find versions available for each vendor. Changing to a newer version upgrades the datastore in place, other changes replace the datastore.",
 				DiffSuppressFunc: caseInsensitiveSuppressor,
 			},
 			"tags": {
@@ -97,16 +97,31 @@ func (r *Datastore) Schema() *schema.Resource {
 				Description: "Volume type, for that will be used as root and data disks as required.",
 			},
 			"volume_size": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Volume size, i.e. how much data storage should be initally allocated. This can be changed later, or autoscaled. When storage_autoscale is enabled, this is the minimum size and growth done by CCX is not reported as a change.",
+				DiffSuppressFunc: volumeSizeSuppressor,
+			},
+			"volume_iops": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Computed:    true,
-				Description: "Volume size, i.e. how much data storage should be initally allocated. This can be changed later, or autoscaled.",
+				Description: "Volume IOPS defines the performance of the disks used for data storage. This is not always configurable, and allowable values depend on the volume type. This can be changed later. When not set, the IOPS are read from CCX, e.g. the baseline of gp3.",
 			},
-			"volume_iops": {
+			"volume_throughput": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Description: "Volume IOPS defines the performance of the disks used for data storage. This is not always configurable, and allowable values depend on the volume type.",
-				Default:     0,
+				Computed:    true,
+				Description: "Volume throughput in MB/s, for volume types where it is configurable, e.g. gp3. Allowable values depend on the volume type. This can be changed later.",
+			},
+			"storage_autoscale": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Storage autoscaling settings. When enabled, CCX grows the volume as it fills up.",
+				Elem:        (storageAutoscale{}).Schema(),
 			},
 			"network_ha_enabled": {
 				Type:        schema.TypeBool,
@@ -129,9 +144,42 @@ func (r *Datastore) Schema() *schema.Resource {
 			"network_az": {
 				Type:        schema.TypeList,
 				Optional:    true,
+				Computed:    true,
 				Description: "Network availability zones. This can be 1) omitted for auto-allocation, 2) a single string, for placing all nodes in the same zone, 3) as many strings as the intended size of the cluster, to place each node separately. The values depend on the chosen cloud and region.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"remove_node_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "IDs of the nodes to remove when size is decreased, as listed in nodes. There must be as many IDs as nodes removed, and the primary cannot be removed. When omitted, the oldest replicas are removed. Only used when size changes.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"add_node": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Nodes to add when size is increased. There must be as many blocks as nodes added. When omitted, the nodes are placed automatically, with the instance size of the newest node. Only used when size changes.",
+				Elem:        (addNode{}).Schema(),
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Nodes of the datastore, in the order they were created.",
+				Elem:        (node{}).Schema(),
+			},
+			"preferred_primary_az": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Availability zone where the primary node should be. When the primary is elsewhere, a healthy replica in this zone is promoted to primary. Defaults to the zone of the current primary.",
+				ConflictsWith: []string{"preferred_primary_host"},
+			},
+			"preferred_primary_host": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Description:   "ID of the host which should be the primary node. When another host is the primary, this host is promoted to primary, if it is a healthy replica. Defaults to the current primary.",
+				ConflictsWith: []string{"preferred_primary_az"},
+			},
 			"firewall": {
 				Type:             schema.TypeList,
 				Optional:         true,
@@ -139,6 +187,12 @@ func (r *Datastore) Schema() *schema.Resource {
 				Elem:             (firewall{}).Schema(),
 				DiffSuppressFunc: firewallDiffSupressor,
 			},
+			"manage_firewall": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the firewall rules of the datastore are managed by the `firewall` argument. Set to `false` when the rules are managed by `ccx_firewall_rule` resources, then `firewall` must not be set, and the rules are not read into it.",
+			},
 			"notifications_enabled": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -179,6 +233,7 @@ func (r *Datastore) Schema() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    false,
 				Computed:    true,
+				Sensitive:   true,
 				Description: "DSN (data source name) to the primary host(s). This is the information that is needed to connect to the cluster - the format depends on the vendor.",
 			},
 			"replica_url": {
@@ -191,8 +246,26 @@ func (r *Datastore) Schema() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    false,
 				Computed:    true,
+				Sensitive:   true,
 				Description: "DSN (data source name) to the replica host(s). This is the information that is needed to connect to the cluster - the format depends on the vendor.",
 			},
+			"ssl_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether connections to the datastore are encrypted with TLS. When enabled, the DSNs and connection strings require TLS.",
+			},
+			"ca_certificate": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "PEM encoded CA certificate, which signed the server certificates of the datastore. Empty when SSL is not enabled. The MariaDB JDBC URLs verify the server certificate, so the certificate must be passed to the driver, e.g. in `serverSslCert`.",
+			},
+			"connection_strings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Connection strings in formats for different clients, e.g. JDBC URLs, libpq keyword/value strings and DSNs listing every host. Formats which are not available for the vendor are empty.",
+				Elem:        (connection{}).Schema(),
+			},
 			"username": {
 				Type:        schema.TypeString,
 				Optional:    false,
@@ -203,8 +276,14 @@ func (r *Datastore) Schema() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    false,
 				Computed:    true,
+				Sensitive:   true,
 				Description: "Password to connect to the datastore - this represents the default user which is automatically created, but other users can be created later.",
 			},
+			"password_rotation_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Any value, e.g. a date. Changing it rotates the password of the default user, which also changes the DSNs and connection strings. Setting it when creating the datastore does not rotate the password.",
+			},
 			"dbname": {
 				Type:        schema.TypeString,
 				Optional:    false,
@@ -216,8 +295,9 @@ func (r *Datastore) Schema() *schema.Resource {
 		ReadContext:   r.Read,
 		UpdateContext: r.Update,
 		DeleteContext: r.Delete,
+		CustomizeDiff: r.CustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: r.Import,
 		},
 	}
 }
@@ -307,6 +387,25 @@ func validateVolume(vendor string, volumeTypes []string, volumeType string, volu
 	return nil
 }
 
+// validateVolumeLimits checks iops and throughput against the limits of the volume type, zero values are not validated
+func validateVolumeLimits(limits map[string]ccx.VolumeLimits, volumeType string, iops, throughput uint64) error {
+	l := limits[volumeType]
+
+	if iops != 0 && l.MaxIOPS == 0 {
+		return fmt.Errorf("volume_iops is not configurable for volume type %q", volumeType)
+	} else if iops != 0 && (iops < l.MinIOPS || iops > l.MaxIOPS) {
+		return fmt.Errorf("volume_iops for volume type %q must be between %d and %d: %d", volumeType, l.MinIOPS, l.MaxIOPS, iops)
+	}
+
+	if throughput != 0 && l.MaxThroughput == 0 {
+		return fmt.Errorf("volume_throughput is not configurable for volume type %q", volumeType)
+	} else if throughput != 0 && (throughput < l.MinThroughput || throughput > l.MaxThroughput) {
+		return fmt.Errorf("volume_throughput for volume type %q must be between %d and %d: %d", volumeType, l.MinThroughput, l.MaxThroughput, throughput)
+	}
+
+	return nil
+}
+
 func validateMaintenanceSettings(m *ccx.MaintenanceSettings) error {
 	if m == nil {
 		return nil
@@ -412,6 +511,10 @@ func (r *Datastore) Create(ctx context.Context, d *schema.ResourceData, _ any) d
 		return diag.FromErr(fmt.Errorf("validating volume type: %w", err))
 	}
 
+	if err := validateStorageAutoscale(c.DBVendor, c.StorageAutoscale, c.VolumeSize); err != nil {
+		return diag.FromErr(fmt.Errorf("validating storage autoscale: %w", err))
+	}
+
 	if c.ParameterGroupID != "" {
 		if err := validateParameterGroupForStore(ctx, r.pgSvc, c, c.ParameterGroupID); err != nil {
 			return diag.FromErr(fmt.Errorf("validating parameter group: %w", err))
@@ -429,6 +532,12 @@ func (r *Datastore) Create(ctx context.Context, d *schema.ResourceData, _ any) d
 		return diag.Errorf("creating stores: %s", err)
 	}
 
+	if p, err := r.switchPrimary(ctx, d, *n); err != nil {
+		errs = append(errs, fmt.Errorf("switching primary: %w", err))
+	} else {
+		n = p
+	}
+
 	if c.ParameterGroupID != "" {
 		err = r.svc.ApplyParameterGroup(ctx, n.ID, c.ParameterGroupID)
 		if err != nil {
@@ -446,8 +555,8 @@ func (r *Datastore) Create(ctx context.Context, d *schema.ResourceData, _ any) d
 		}
 	}
 
-	if len(c.FirewallRules) != 0 {
-		if err := r.svc.SetFirewallRules(ctx, n.ID, c.FirewallRules); err != nil {
+	if len(c.FirewallRules) != 0 && manageFirewall(d) {
+		if err := r.setFirewallRules(ctx, n.ID, c.FirewallRules); err != nil {
 			errs = append(errs, fmt.Errorf("%w: setting: %w", ccx.ErrFirewallRules, err))
 		} else {
 			n.FirewallRules = c.FirewallRules
@@ -466,13 +575,9 @@ func (r *Datastore) Create(ctx context.Context, d *schema.ResourceData, _ any) d
 	return nil
 }
 
+// Read does not validate the arguments, they might not match the datastore any longer, e.g. after a resize outside of terraform
 func (r *Datastore) Read(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
-	c, err := datastoreFromSchema(d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	n, err := r.svc.Read(ctx, c.ID)
+	n, err := r.svc.Read(ctx, d.Id())
 	if errors.Is(err, ccx.ErrResourceNotFound) {
 		d.SetId("")
 		return nil
@@ -499,16 +604,30 @@ func (r *Datastore) Update(ctx context.Context, d *schema.ResourceData, _ any) d
 		return diag.FromErr(err)
 	}
 
-	n := &c
+	c.Hosts = old.Hosts
+
+	// the datastore as read, with the changes applied below, so that the computed attributes e.g. the connection strings are kept
+	n := old
 
 	if d.HasChanges("maintenance_day_of_week", "maintenance_start_hour", "maintenance_end_hour") {
-		n.MaintenanceSettings = getMaintenanceSettings(d)
+		c.MaintenanceSettings = getMaintenanceSettings(d)
 
-		if err := validateMaintenanceSettings(n.MaintenanceSettings); err != nil {
+		if err := validateMaintenanceSettings(c.MaintenanceSettings); err != nil {
 			return diag.FromErr(fmt.Errorf("validating maintenance settings: %w", err))
 		}
 	}
 
+	if d.HasChange("storage_autoscale") {
+		if err := validateStorageAutoscale(c.DBVendor, c.StorageAutoscale, c.VolumeSize); err != nil {
+			return diag.FromErr(fmt.Errorf("validating storage autoscale: %w", err))
+		}
+	}
+
+	if a := c.StorageAutoscale; a != nil && a.Enabled && c.VolumeSize <= old.VolumeSize {
+		// volume_size is the minimum when autoscaling, the volume might have grown beyond it
+		c.VolumeSize = old.VolumeSize
+	}
+
 	if old.VolumeSize > c.VolumeSize {
 		return diag.Errorf("decreasing volume_size is not supported, from %dGB to %dGB", old.VolumeSize, c.VolumeSize)
 	} else if old.VolumeSize != c.VolumeSize && (old.VolumeSize+10) >= c.VolumeSize {
@@ -517,12 +636,39 @@ func (r *Datastore) Update(ctx context.Context, d *schema.ResourceData, _ any) d
 
 	var errs []error
 
-	if d.HasChangesExcept("firewall", "parameter_group") {
+	if d.HasChange("db_version") {
+		if err := r.svc.UpgradeDBVersion(ctx, *old, c.DBVersion); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if old, err = r.svc.Read(ctx, c.ID); err != nil {
+			return diag.FromErr(err)
+		}
+
+		n = old
+	}
+
+	// credentials are computed, they only change in the plan when the password is rotated
+	except := append([]string{"firewall", "manage_firewall", "parameter_group", "db_version", "preferred_primary_az", "preferred_primary_host", "remove_node_ids", "add_node", "password_rotation_trigger"}, credentialAttributes...)
+
+	if d.HasChangesExcept(except...) {
 		if n, err = r.svc.Update(ctx, *old, c); err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
+	if d.HasChanges("preferred_primary_az", "preferred_primary_host") {
+		if n, err = r.switchPrimary(ctx, d, *n); err != nil {
+			return diag.FromErr(fmt.Errorf("switching primary: %w", err))
+		}
+	}
+
+	if d.HasChange("password_rotation_trigger") {
+		if n, err = r.rotatePassword(ctx, n.ID); err != nil {
+			return diag.FromErr(fmt.Errorf("rotating password: %w", err))
+		}
+	}
+
 	if d.HasChange("parameter_group") {
 		if err := r.svc.ApplyParameterGroup(ctx, n.ID, c.ParameterGroupID); err != nil {
 			errs = append(errs, fmt.Errorf("%w: %w", ccx.ErrApplyParameterGroup, err))
@@ -531,9 +677,14 @@ func (r *Datastore) Update(ctx context.Context, d *schema.ResourceData, _ any) d
 		}
 	}
 
-	if d.HasChange("firewall") {
-		if err := r.svc.SetFirewallRules(ctx, n.ID, c.FirewallRules); err != nil {
+	if d.HasChanges("firewall", "manage_firewall") && manageFirewall(d) {
+		if err := r.setFirewallRules(ctx, n.ID, matchAllPorts(d, c.FirewallRules)); err != nil {
 			errs = append(errs, fmt.Errorf("%w: %w", ccx.ErrFirewallRules, err))
+
+			// store the rules which exist, so that the next plan retries the changes which failed
+			if fw, err := r.svc.GetFirewallRules(ctx, n.ID); err == nil {
+				n.FirewallRules = fw
+			}
 		} else {
 			n.FirewallRules = c.FirewallRules
 		}
@@ -554,13 +705,18 @@ func (r *Datastore) Update(ctx context.Context, d *schema.ResourceData, _ any) d
 	return nil
 }
 
-func (r *Datastore) Delete(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
-	c, err := datastoreFromSchema(d)
+// setFirewallRules sets the rules of the datastore, keeping the rules of the ip sets it references
+func (r *Datastore) setFirewallRules(ctx context.Context, storeID string, rules []ccx.FirewallRule) error {
+	rules, err := expandIPSets(ctx, r.svc, storeID, rules)
 	if err != nil {
-		return diag.FromErr(err)
+		return err
 	}
 
-	err = r.svc.Delete(ctx, c.ID)
+	return r.svc.SetFirewallRules(ctx, storeID, rules)
+}
+
+func (r *Datastore) Delete(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	err := r.svc.Delete(ctx, d.Id())
 	if err != nil && !errors.Is(err, ccx.ErrResourceNotFound) {
 		return diag.FromErr(err)
 	}
@@ -570,6 +726,178 @@ func (r *Datastore) Delete(ctx context.Context, d *schema.ResourceData, _ any) d
 	return nil
 }
 
+// CustomizeDiff validates volume iops and throughput and the firewall arguments, marks the credentials as changing when the password is rotated,
+// and forces a new datastore when db_version changes and CCX cannot upgrade the database in place
+func (r *Datastore) CustomizeDiff(ctx context.Context, d *schema.ResourceDiff, _ any) error {
+	if err := r.validateVolumeLimitsDiff(ctx, d); err != nil {
+		return err
+	}
+
+	if err := rotatePasswordDiff(d); err != nil {
+		return err
+	}
+
+	if err := validateFirewallDiff(d); err != nil {
+		return err
+	}
+
+	return r.forceNewDBVersionDiff(ctx, d)
+}
+
+// validateVolumeLimitsDiff validates volume iops and throughput at plan time, when they are configured or change
+// iops and throughput which are not configured are read from CCX, so they are recomputed for a new volume type instead of carried over
+func (r *Datastore) validateVolumeLimitsDiff(ctx context.Context, d *schema.ResourceDiff) error {
+	if r.contentSvc == nil || !d.HasChanges("volume_iops", "volume_throughput", "volume_type") {
+		return nil
+	}
+
+	if d.Id() != "" && d.HasChange("volume_type") {
+		for _, key := range []string{"volume_iops", "volume_throughput"} {
+			if isConfigured(d.GetRawConfig(), key) {
+				continue
+			}
+
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	iops := volumeLimitToValidate(d, "volume_iops")
+	throughput := volumeLimitToValidate(d, "volume_throughput")
+	volumeType, _ := d.Get("volume_type").(string)
+	cloud, _ := d.Get("cloud_provider").(string)
+
+	if (iops == 0 && throughput == 0) || volumeType == "" || cloud == "" {
+		return nil
+	}
+
+	limits, err := r.contentSvc.VolumeLimits(ctx, cloud)
+	if err != nil {
+		return fmt.Errorf("loading volume limits: %w", err)
+	}
+
+	return validateVolumeLimits(limits, volumeType, iops, throughput)
+}
+
+// volumeLimitToValidate returns the iops or throughput to validate, 0 when it is neither configured nor changing, e.g. the value read from CCX
+func volumeLimitToValidate(d *schema.ResourceDiff, key string) uint64 {
+	if !isConfigured(d.GetRawConfig(), key) && !d.HasChange(key) {
+		return 0
+	}
+
+	v, _ := d.Get(key).(int)
+
+	return uint64(v)
+}
+
+func (r *Datastore) forceNewDBVersionDiff(ctx context.Context, d *schema.ResourceDiff) error {
+	if d.Id() == "" || !d.HasChange("db_version") || r.contentSvc == nil {
+		return nil
+	}
+
+	o, n := d.GetChange("db_version")
+
+	from, _ := o.(string)
+	to, _ := n.(string)
+
+	if from == "" || to == "" || strings.EqualFold(from, to) {
+		return nil
+	}
+
+	vendors, err := r.contentSvc.DBVendors(ctx)
+	if err != nil {
+		return fmt.Errorf("loading db vendor information: %w", err)
+	}
+
+	vendor, _ := d.Get("db_vendor").(string)
+
+	if !ccx.UpgradePathSupported(vendors, vendorFromAlias(vendor), from, to) {
+		tflog.Info(ctx, "db_version upgrade is not supported, datastore will be replaced", map[string]any{
+			"id": d.Id(), "from": from, "to": to,
+		})
+
+		return d.ForceNew("db_version")
+	}
+
+	return nil
+}
+
+// Import a datastore either by ID, or by name in the form name:<cluster_name>
+// all arguments, including network_az, are filled from the datastore, so that the first plan after import is empty
+func (r *Datastore) Import(ctx context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+	id := d.Id()
+
+	if name, ok := strings.CutPrefix(id, "name:"); ok {
+		var err error
+		if id, err = findDatastoreByName(ctx, r.svc, name); err != nil {
+			return nil, err
+		}
+	}
+
+	n, err := r.svc.Read(ctx, id)
+	if errors.Is(err, ccx.ErrResourceNotFound) {
+		return nil, fmt.Errorf("datastore %q not found", id)
+	} else if err != nil {
+		return nil, fmt.Errorf("reading datastore %q: %w", id, err)
+	}
+
+	// the default is not set on import, firewall rules are imported into the firewall argument
+	if err := d.Set("manage_firewall", true); err != nil {
+		return nil, fmt.Errorf("setting manage_firewall: %w", err)
+	}
+
+	if err := fillSchemaFromDatastore(*n, d); err != nil {
+		return nil, fmt.Errorf("setting schema: %w", err)
+	}
+
+	if err := setStrings(d, "tags", n.UserTags()); err != nil {
+		return nil, fmt.Errorf("setting tags: %w", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func findDatastoreByName(ctx context.Context, svc ccx.DatastoresService, name string) (string, error) {
+	ls, err := svc.List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("listing datastores: %w", err)
+	}
+
+	var ids []string
+
+	for _, c := range ls {
+		if c.Name == name {
+			ids = append(ids, c.ID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("datastore with name %q not found", name)
+	case 1:
+		return ids[0], nil
+	}
+
+	return "", fmt.Errorf("found %d datastores with name %q, import by id instead: %s", len(ids), name, strings.Join(ids, ", "))
+}
+
+// hostAzs returns the availability zones of the hosts, in the order the hosts were created
+func hostAzs(hosts []ccx.Host) []string {
+	hosts = slices.Clone(hosts)
+
+	slices.SortStableFunc(hosts, func(a, b ccx.Host) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	ls := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		ls = append(ls, h.AZ)
+	}
+
+	return ls
+}
+
 func (r *Datastore) instanceSizeDiffSupressor(_, oldValue, newValue string, d *schema.ResourceData) bool {
 	if d.IsNewResource() || r.contentSvc == nil {
 		// contentSvc might not have been initialized yet (configured not run by terraform)
@@ -634,11 +962,27 @@ func datastoreFromSchema(d *schema.ResourceData) (ccx.Datastore, error) {
 		VolumeType:       getString(d, "volume_type"),
 		VolumeSize:       uint64(getInt(d, "volume_size")),
 		VolumeIOPS:       uint64(getInt(d, "volume_iops")),
+		VolumeThroughput: uint64(getInt(d, "volume_throughput")),
 		HAEnabled:        getBool(d, "network_ha_enabled"),
 		ParameterGroupID: getString(d, "parameter_group"),
 		VpcUUID:          getString(d, "network_vpc_uuid"),
 	}
 
+	// iops and throughput which are not configured are unknown when the volume type changes, CCX chooses them for the new type
+	if isUnknown(d.GetRawPlan(), "volume_iops") {
+		c.VolumeIOPS = 0
+	}
+
+	if isUnknown(d.GetRawPlan(), "volume_throughput") {
+		c.VolumeThroughput = 0
+	}
+
+	if ids := getStrings(d, "remove_node_ids"); len(ids) != 0 {
+		c.RemoveNodeIDs = ids
+	}
+
+	c.AddNodes = getAddNodes(d)
+
 	if azs, hasAzs := getAzs(d); hasAzs && len(azs) == int(c.Size) {
 		c.AvailabilityZones = azs
 	} else if hasAzs {
@@ -657,6 +1001,7 @@ func datastoreFromSchema(d *schema.ResourceData) (ccx.Datastore, error) {
 
 	c.Notifications = getNotifications(d)
 	c.MaintenanceSettings = getMaintenanceSettings(d)
+	c.StorageAutoscale = getStorageAutoscale(d)
 
 	return c, nil
 }
@@ -713,6 +1058,10 @@ func fillSchemaFromDatastore(c ccx.Datastore, d *schema.ResourceData) error {
 		return err
 	}
 
+	if err = d.Set("volume_throughput", c.VolumeThroughput); err != nil {
+		return err
+	}
+
 	if err = d.Set("network_vpc_uuid", c.VpcUUID); err != nil {
 		return err
 	}
@@ -741,6 +1090,18 @@ func fillSchemaFromDatastore(c ccx.Datastore, d *schema.ResourceData) error {
 		return err
 	}
 
+	if err = d.Set("ssl_enabled", c.SSLEnabled); err != nil {
+		return err
+	}
+
+	if err = d.Set("ca_certificate", c.CACertificate); err != nil {
+		return err
+	}
+
+	if err = setConnection(d, c.Connection); err != nil {
+		return err
+	}
+
 	if err = d.Set("username", c.Username); err != nil {
 		return err
 	}
@@ -753,16 +1114,24 @@ func fillSchemaFromDatastore(c ccx.Datastore, d *schema.ResourceData) error {
 		return err
 	}
 
-	if azs, ok := getAzs(d); ok { // do not set azs from upstream
-		if err = setStrings(d, "network_az", azs); err != nil {
-			return err
-		}
+	if err = setStrings(d, "network_az", networkAzs(d, c.Hosts)); err != nil {
+		return err
+	}
+
+	if err = setNodes(d, c.Hosts); err != nil {
+		return err
 	}
 
-	if err = setFirewalls(d, c.FirewallRules); err != nil {
+	if err = setPrimary(d, c.Hosts); err != nil {
 		return err
 	}
 
+	if manageFirewall(d) {
+		if err = setFirewalls(d, c.FirewallRules); err != nil {
+			return err
+		}
+	}
+
 	if err = setNotifications(d, c.Notifications); err != nil {
 		return err
 	}
@@ -773,5 +1142,9 @@ func fillSchemaFromDatastore(c ccx.Datastore, d *schema.ResourceData) error {
 		}
 	}
 
+	if err = setStorageAutoscale(d, c.StorageAutoscale); err != nil {
+		return err
+	}
+
 	return nil
 }
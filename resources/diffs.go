@@ -68,3 +68,18 @@ func vendorSuppressor(_, oldValue, newValue string, _ *schema.ResourceData) bool
 	o, n := vendorFromAlias(oldValue), vendorFromAlias(newValue)
 	return o == n
 }
+
+// cidrSuppressor suppresses the diff when both are the same CIDR, e.g. 10.0.0.5 in the configuration and 10.0.0.5/32 read from CCX
+func cidrSuppressor(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	o, err := ccx.CanonicalCIDR(oldValue)
+	if err != nil {
+		return false
+	}
+
+	n, err := ccx.CanonicalCIDR(newValue)
+	if err != nil {
+		return false
+	}
+
+	return o == n
+}
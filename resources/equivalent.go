@@ -0,0 +1,140 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// equivalence of two spellings of a string, it replaces the DiffSuppressFunc of the SDK for framework resources
+type equivalence int
+
+const (
+	caseInsensitive equivalence = iota
+	vendorAlias
+)
+
+func (e equivalence) equal(a, b string) bool {
+	switch e {
+	case vendorAlias:
+		return vendorFromAlias(a) == vendorFromAlias(b)
+	default:
+		return strings.EqualFold(a, b)
+	}
+}
+
+func (e equivalence) String() string {
+	switch e {
+	case vendorAlias:
+		return "vendorAlias"
+	default:
+		return "caseInsensitive"
+	}
+}
+
+// equivalentStringType is a string type, which keeps the configured spelling in state when CCX returns an equivalent spelling
+type equivalentStringType struct {
+	basetypes.StringType
+	equivalence equivalence
+}
+
+func (t equivalentStringType) Equal(o attr.Type) bool {
+	other, ok := o.(equivalentStringType)
+	if !ok {
+		return false
+	}
+
+	return t.equivalence == other.equivalence
+}
+
+func (t equivalentStringType) String() string {
+	return fmt.Sprintf("equivalentStringType(%s)", t.equivalence)
+}
+
+func (t equivalentStringType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return equivalentStringValue{StringValue: in, equivalence: t.equivalence}, nil
+}
+
+func (t equivalentStringType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	v, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := v.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", v)
+	}
+
+	return equivalentStringValue{StringValue: s, equivalence: t.equivalence}, nil
+}
+
+func (t equivalentStringType) ValueType(_ context.Context) attr.Value {
+	return equivalentStringValue{equivalence: t.equivalence}
+}
+
+type equivalentStringValue struct {
+	basetypes.StringValue
+	equivalence equivalence
+}
+
+func (v equivalentStringValue) Equal(o attr.Value) bool {
+	other, ok := o.(equivalentStringValue)
+	if !ok {
+		return false
+	}
+
+	return v.equivalence == other.equivalence && v.StringValue.Equal(other.StringValue)
+}
+
+func (v equivalentStringValue) Type(_ context.Context) attr.Type {
+	return equivalentStringType{equivalence: v.equivalence}
+}
+
+func (v equivalentStringValue) StringSemanticEquals(_ context.Context, o basetypes.StringValuable) (bool, diag.Diagnostics) {
+	other, ok := o.(equivalentStringValue)
+	if !ok {
+		return false, nil
+	}
+
+	return v.equivalentTo(other), nil
+}
+
+func newEquivalentString(s string, e equivalence) equivalentStringValue {
+	return equivalentStringValue{StringValue: basetypes.NewStringValue(s), equivalence: e}
+}
+
+// equivalentTo reports whether both values are spellings of the same value
+func (v equivalentStringValue) equivalentTo(o equivalentStringValue) bool {
+	return v.equivalence.equal(v.ValueString(), o.ValueString())
+}
+
+// useEquivalentState keeps the spelling in state when the configured spelling is equivalent, e.g. the vendor
+// stored by the SDK versions of the provider, which read the name used by CCX instead of the configured alias
+type useEquivalentState struct {
+	equivalence equivalence
+}
+
+func (m useEquivalentState) Description(_ context.Context) string {
+	return "Keeps the value in state when the configured value is an equivalent spelling of it."
+}
+
+func (m useEquivalentState) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useEquivalentState) PlanModifyString(_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	if m.equivalence.equal(req.StateValue.ValueString(), req.PlanValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
@@ -1,14 +1,21 @@
 package resources
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
 )
 
+const firewallSourceDescription = "CIDR source for the firewall rule, i.e. from where the cluster should be accesible. IPv4 and IPv6 are supported, a single IP is converted to a /32 or /128 CIDR."
+
+const firewallPortsDescription = "Names of the services, e.g. the database, which the source has access to. Other ports, e.g. of exporters or admin services, stay closed. `[\"*\"]` opens all ports, e.g. to open all ports again after setting services. When not set, all ports are open for a new rule, and the ports are read from CCX."
+
 type firewall struct{}
 
 func (f firewall) Schema() *schema.Resource {
@@ -19,15 +26,29 @@ func (f firewall) Schema() *schema.Resource {
 				Computed: true,
 			},
 			"source": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      firewallSourceDescription + " Either `source` or `ip_set` must be set.",
+				ValidateDiagFunc: validateCIDR,
+				DiffSuppressFunc: cidrSuppressor,
+			},
+			"ip_set": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "CIDR source for the firewall rule, i.e. from where the cluster should be accesible.",
+				Optional:    true,
+				Description: "Name of a `ccx_ip_set` attached to the datastore. Its rules are managed by the IP set, and are kept by the datastore. Either `source` or `ip_set` must be set.",
 			},
 			"description": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Description of this firewall rule.",
 			},
+			"ports": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Description: firewallPortsDescription,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -87,13 +108,8 @@ func firewallsSame(ls1, ls2 []ccx.FirewallRule) bool {
 		return false
 	}
 
-	haveM := make(map[ccx.FirewallRule]struct{}, len(ls1))
-	for _, f := range ls1 {
-		haveM[f] = struct{}{}
-	}
-
 	for _, f := range ls2 {
-		if _, ok := haveM[f]; !ok {
+		if !slices.ContainsFunc(ls1, f.Matches) {
 			return false
 		}
 	}
@@ -101,36 +117,250 @@ func firewallsSame(ls1, ls2 []ccx.FirewallRule) bool {
 	return true
 }
 
+// setFirewalls sets the rules into the firewall blocks, the rules of an ip set are set as one block referencing the set
+// references are kept when the ip set has no rules on the datastore yet, e.g. when the datastore was just created,
+// and so are the ports of rules which keep opening all ports, as CCX lists the ports of the datastore for them
 func setFirewalls(d *schema.ResourceData, firewalls []ccx.FirewallRule) error {
 	value := make([]map[string]any, 0, len(firewalls))
+	opened := keptAllPortsSources(d)
+
+	var ipSets []string
+
+	if current, err := getFirewalls(d); err == nil {
+		for _, f := range current {
+			if name, ok := ipSetReference(f); ok {
+				ipSets = append(ipSets, name)
+			}
+		}
+	}
 
 	slices.SortStableFunc(firewalls, func(a, b ccx.FirewallRule) int {
 		return strings.Compare(a.Source, b.Source)
 	})
 
 	for _, f := range firewalls {
+		if name, ok := ipSetName(f); ok {
+			ipSets = append(ipSets, name)
+			continue
+		}
+
+		ports := f.Ports
+		if slices.Contains(opened, f.Source) {
+			ports = []string{ccx.AllPorts}
+		}
+
 		value = append(value, map[string]any{
 			"id":          f.Source,
 			"source":      f.Source,
 			"description": f.Description,
+			"ports":       ports,
+		})
+	}
+
+	slices.Sort(ipSets)
+
+	for _, name := range slices.Compact(ipSets) {
+		value = append(value, map[string]any{
+			"id":     ipSetDescription(name),
+			"ip_set": name,
 		})
 	}
 
 	return d.Set("firewall", value)
 }
 
+// allPortsSources returns the sources of the rules which opened all ports before the change, i.e. in state
+func allPortsSources(d *schema.ResourceData) []string {
+	old, _ := d.GetChange("firewall")
+
+	return allPortsSourcesOf(old)
+}
+
+// keptAllPortsSources returns the sources of the rules which open all ports both before and after the change
+func keptAllPortsSources(d *schema.ResourceData) []string {
+	old, nw := d.GetChange("firewall")
+	after := allPortsSourcesOf(nw)
+
+	return slices.DeleteFunc(allPortsSourcesOf(old), func(s string) bool {
+		return !slices.Contains(after, s)
+	})
+}
+
+func allPortsSourcesOf(v any) []string {
+	ls, _ := v.([]any)
+
+	var sources []string
+
+	for _, v := range ls {
+		if m, ok := v.(map[string]any); ok {
+			if f, err := firewallFromMapAny(m); err == nil && f.OpensAllPorts() {
+				sources = append(sources, f.Source)
+			}
+		}
+	}
+
+	return sources
+}
+
+// matchAllPorts clears the ports of the rules which opened all ports before the change, so that they match the rules in CCX,
+// which lists the ports of the datastore for them, while a rule which opened other ports is replaced by one opening all ports
+func matchAllPorts(d *schema.ResourceData, rules []ccx.FirewallRule) []ccx.FirewallRule {
+	opened := allPortsSources(d)
+	ls := slices.Clone(rules)
+
+	for i, f := range ls {
+		if f.OpensAllPorts() && slices.Contains(opened, f.Source) {
+			ls[i].Ports = nil
+		}
+	}
+
+	return ls
+}
+
+// validatePorts validates that AllPorts is not set together with names of services
+func validatePorts(ports []string) error {
+	if len(ports) > 1 && slices.Contains(ports, ccx.AllPorts) {
+		return fmt.Errorf(`ports must be either ["%s"] to open all ports, or names of services`, ccx.AllPorts)
+	}
+
+	return nil
+}
+
+// validatePortsDiff validates the ports of a ccx_firewall_rule or ccx_ip_set at plan time
+func validatePortsDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if !d.NewValueKnown("ports") {
+		return nil
+	}
+
+	return validatePorts(listToStrings(d.Get("ports")))
+}
+
+// ipSetReference returns the name of the ip set, when the rule is a firewall block referencing it
+func ipSetReference(f ccx.FirewallRule) (string, bool) {
+	name, ok := ipSetName(f)
+	return name, ok && f.Source == ""
+}
+
+// expandIPSets replaces the rules referencing an ip set by the rules of the set on the datastore, as they are managed by ccx_ip_set
+func expandIPSets(ctx context.Context, svc ccx.DatastoresService, storeID string, rules []ccx.FirewallRule) ([]ccx.FirewallRule, error) {
+	if !slices.ContainsFunc(rules, func(f ccx.FirewallRule) bool {
+		_, ok := ipSetReference(f)
+		return ok
+	}) {
+		return rules, nil
+	}
+
+	have, err := svc.GetFirewallRules(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting firewall rules: %w", err)
+	}
+
+	ls := make([]ccx.FirewallRule, 0, len(rules))
+
+	for _, f := range rules {
+		if name, ok := ipSetReference(f); ok {
+			ls = append(ls, ipSetRules(have, name)...)
+		} else {
+			ls = append(ls, f)
+		}
+	}
+
+	return ls, nil
+}
+
+// firewallFromMapAny returns the rule of a firewall block, a block referencing an ip set returns a rule without source,
+// which is replaced by the rules of the ip set before setting the rules of the datastore
 func firewallFromMapAny(m map[string]any) (*ccx.FirewallRule, error) {
 	var f ccx.FirewallRule
 
+	if name, ok := m["ip_set"].(string); ok && name != "" {
+		if s, ok := m["source"].(string); ok && s != "" {
+			return nil, fmt.Errorf("source and ip_set must not both be set")
+		}
+
+		f.Description = ipSetDescription(name)
+
+		return &f, nil
+	}
+
 	if v, ok := m["description"].(string); ok {
 		f.Description = v
 	}
 
-	if v, ok := m["source"].(string); ok {
-		f.Source = v
+	if v, ok := m["ports"].([]any); ok {
+		for _, p := range v {
+			if s, ok := p.(string); ok && s != "" {
+				f.Ports = append(f.Ports, s)
+			}
+		}
+
+		slices.Sort(f.Ports)
+
+		if err := validatePorts(f.Ports); err != nil {
+			return nil, err
+		}
+	}
+
+	if v, ok := m["source"].(string); ok && v != "" {
+		s, err := ccx.CanonicalCIDR(v)
+		if err != nil {
+			return nil, err
+		}
+
+		f.Source = s
 	} else {
-		return nil, fmt.Errorf("mandatory field source is missing")
+		return nil, fmt.Errorf("either source or ip_set must be set")
 	}
 
 	return &f, nil
 }
+
+// manageFirewall reports whether the firewall rules are managed by the firewall argument of the datastore
+// the attribute is null in states written before manage_firewall existed, which were managed
+func manageFirewall(d *schema.ResourceData) bool {
+	if s := d.GetRawState(); s.IsKnown() && !s.IsNull() && s.Type().IsObjectType() && s.Type().HasAttribute("manage_firewall") {
+		if v := s.GetAttr("manage_firewall"); v.IsNull() && !d.HasChange("manage_firewall") {
+			return true
+		}
+	}
+
+	v, ok := d.Get("manage_firewall").(bool)
+
+	return !ok || v
+}
+
+// validateFirewallDiff validates that firewall is not set when manage_firewall is false, and that each rule has either source or ip_set
+func validateFirewallDiff(d *schema.ResourceDiff) error {
+	ls, _ := d.Get("firewall").([]any)
+
+	if manage, ok := d.Get("manage_firewall").(bool); ok && !manage && len(ls) != 0 {
+		return fmt.Errorf("firewall must not be set when manage_firewall is false, use ccx_firewall_rule resources instead")
+	}
+
+	if !d.NewValueKnown("firewall") {
+		return nil
+	}
+
+	_, err := parseRawFirewalls(ls)
+
+	return err
+}
+
+// validateCIDR validates the source of a firewall rule at plan time
+func validateCIDR(v any, p cty.Path) diag.Diagnostics {
+	s, ok := v.(string)
+	if !ok {
+		return diag.Diagnostics{{Severity: diag.Error, Summary: "expected a string", AttributePath: p}}
+	}
+
+	if _, err := ccx.CanonicalCIDR(s); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "invalid CIDR",
+			Detail:        err.Error() + ", expected an IPv4 or IPv6 CIDR, e.g. 10.0.0.0/16 or 2001:db8::/32, or a single IP",
+			AttributePath: p,
+		}}
+	}
+
+	return nil
+}
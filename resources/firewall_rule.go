@@ -0,0 +1,205 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+)
+
+const firewallRuleDoc = `
+A firewall rule allows access to a datastore from a block of IP addresses. Rules can be declared in different modules, e.g. by the teams owning the CIDRs.
+
+Set ` + "`manage_firewall = false`" + ` on the datastore when using this resource, otherwise the ` + "`firewall`" + ` argument of the datastore removes the rules.`
+
+type FirewallRule struct {
+	svc ccx.DatastoresService
+}
+
+func (r *FirewallRule) Schema() *schema.Resource {
+	return &schema.Resource{
+		Description: firewallRuleDoc,
+		Schema: map[string]*schema.Schema{
+			"datastore_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the datastore.",
+			},
+			"source": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      firewallSourceDescription,
+				ValidateDiagFunc: validateCIDR,
+				DiffSuppressFunc: cidrSuppressor,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Description of this firewall rule.",
+			},
+			"ports": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: firewallPortsDescription,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		CustomizeDiff: validatePortsDiff,
+		CreateContext: r.Create,
+		ReadContext:   r.Read,
+		DeleteContext: r.Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: r.Import,
+		},
+	}
+}
+
+func (r *FirewallRule) Create(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	storeID := getString(d, "datastore_id")
+	f := firewallRuleFromSchema(d)
+
+	if err := r.svc.CreateFirewallRule(ctx, storeID, f); err != nil {
+		return diag.Errorf("creating firewall rule (source=%s, description=%s): %s", f.Source, f.Description, err)
+	}
+
+	d.SetId(firewallRuleID(storeID, f.Source))
+
+	return r.Read(ctx, d, nil)
+}
+
+func (r *FirewallRule) Read(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	storeID, source, err := parseFirewallRuleID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	f, err := r.findFirewallRule(ctx, storeID, source)
+	if errors.Is(err, ccx.ErrResourceNotFound) {
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(fillSchemaFromFirewallRule(storeID, *f, d))
+}
+
+func (r *FirewallRule) Delete(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	storeID := getString(d, "datastore_id")
+
+	f := firewallRuleFromSchema(d)
+
+	if err := r.svc.DeleteFirewallRule(ctx, storeID, f); err != nil {
+		return diag.Errorf("deleting firewall rule (source=%s, description=%s): %s", f.Source, f.Description, err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// Import a firewall rule by <datastore_id>/<cidr>, the CIDR may be in any form, e.g. 2a9d6e1c-0c7f-4b66-a3e2-6c9c4b5d0e7f/10.0.0.0/16
+func (r *FirewallRule) Import(ctx context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+	storeID, source, err := parseFirewallRuleID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := r.findFirewallRule(ctx, storeID, source)
+	if errors.Is(err, ccx.ErrResourceNotFound) {
+		return nil, fmt.Errorf("firewall rule %q not found", d.Id())
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := fillSchemaFromFirewallRule(storeID, *f, d); err != nil {
+		return nil, fmt.Errorf("setting schema: %w", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// findFirewallRule returns the rule of the datastore with the source, or ccx.ErrResourceNotFound when either does not exist
+func (r *FirewallRule) findFirewallRule(ctx context.Context, storeID, source string) (*ccx.FirewallRule, error) {
+	ls, err := r.svc.GetFirewallRules(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting firewall rules: %w", err)
+	}
+
+	for _, f := range ls {
+		if s, err := ccx.CanonicalCIDR(f.Source); err == nil && s == source || f.Source == source {
+			return &f, nil
+		}
+	}
+
+	return nil, ccx.ErrResourceNotFound
+}
+
+func firewallRuleID(storeID, source string) string {
+	return storeID + "/" + source
+}
+
+// parseFirewallRuleID splits the ID at the first slash, as the CIDR contains a slash too, the CIDR is returned in canonical form
+func parseFirewallRuleID(id string) (storeID, source string, err error) {
+	storeID, source, ok := strings.Cut(id, "/")
+	if !ok || storeID == "" || source == "" {
+		return "", "", fmt.Errorf("invalid firewall rule id %q, expected <datastore_id>/<cidr>", id)
+	}
+
+	if source, err = ccx.CanonicalCIDR(source); err != nil {
+		return "", "", fmt.Errorf("invalid firewall rule id %q: %w", id, err)
+	}
+
+	return storeID, source, nil
+}
+
+func firewallRuleFromSchema(d *schema.ResourceData) ccx.FirewallRule {
+	f := ccx.FirewallRule{
+		Source:      getString(d, "source"),
+		Description: getString(d, "description"),
+	}
+
+	// the source is validated at plan time
+	if s, err := ccx.CanonicalCIDR(f.Source); err == nil {
+		f.Source = s
+	}
+
+	if ports := getStrings(d, "ports"); len(ports) != 0 {
+		f.Ports = ports
+	}
+
+	return f
+}
+
+func fillSchemaFromFirewallRule(storeID string, f ccx.FirewallRule, d *schema.ResourceData) error {
+	d.SetId(firewallRuleID(storeID, f.Source))
+
+	if err := d.Set("datastore_id", storeID); err != nil {
+		return err
+	}
+
+	if err := d.Set("source", f.Source); err != nil {
+		return err
+	}
+
+	if err := d.Set("description", f.Description); err != nil {
+		return err
+	}
+
+	// CCX lists the ports of the datastore for a rule opening all ports
+	if slices.Equal(getStrings(d, "ports"), []string{ccx.AllPorts}) {
+		return nil
+	}
+
+	return setStrings(d, "ports", f.Ports)
+}
@@ -0,0 +1,197 @@
+package resources
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirewallRule(t *testing.T) {
+	m, p := mockProvider(t)
+
+	var rules []ccx.FirewallRule
+
+	m.datastore.EXPECT().CreateFirewallRule(mock.Anything, "datastore-1", mock.Anything).RunAndReturn(func(_ context.Context, _ string, f ccx.FirewallRule) error {
+		rules = append(rules, f)
+		return nil
+	})
+	m.datastore.EXPECT().GetFirewallRules(mock.Anything, "datastore-1").RunAndReturn(func(context.Context, string) ([]ccx.FirewallRule, error) {
+		ls := slices.Clone(rules)
+
+		// CCX lists the ports of the datastore for rules opening all ports
+		for i, f := range ls {
+			if f.OpensAllPorts() {
+				ls[i].Ports = []string{"exporter", "postgres"}
+			}
+		}
+
+		return ls, nil
+	})
+	m.datastore.EXPECT().DeleteFirewallRule(mock.Anything, "datastore-1", mock.Anything).RunAndReturn(func(_ context.Context, _ string, f ccx.FirewallRule) error {
+		rules = slices.DeleteFunc(rules, func(r ccx.FirewallRule) bool {
+			return r.Source == f.Source
+		})
+		return nil
+	})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"ccx": func() (*schema.Provider, error) {
+				return p, nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "ccx_firewall_rule" "office" {
+  datastore_id = "datastore-1"
+  source       = "10.0.0.0/16"
+  description  = "office"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ccx_firewall_rule.office", "id", "datastore-1/10.0.0.0/16"),
+					resource.TestCheckResourceAttr("ccx_firewall_rule.office", "datastore_id", "datastore-1"),
+					resource.TestCheckResourceAttr("ccx_firewall_rule.office", "source", "10.0.0.0/16"),
+					resource.TestCheckResourceAttr("ccx_firewall_rule.office", "description", "office"),
+				),
+			},
+			{
+				ResourceName:      "ccx_firewall_rule.office",
+				ImportState:       true,
+				ImportStateId:     "datastore-1/10.0.0.0/16",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "ccx_firewall_rule.office",
+				ImportState:   true,
+				ImportStateId: "datastore-1/192.168.0.0/24",
+				ExpectError:   regexp.MustCompile(`firewall rule "datastore-1/192.168.0.0/24" not found`),
+			},
+			{
+				Config: `
+resource "ccx_firewall_rule" "office" {
+  datastore_id = "datastore-1"
+  source       = "10.0.0.0/16"
+  description  = "head office"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ccx_firewall_rule.office", "description", "head office"),
+					func(*terraform.State) error {
+						require.Equal(t, []ccx.FirewallRule{{Source: "10.0.0.0/16", Description: "head office"}}, rules)
+						return nil
+					},
+				),
+			},
+			{
+				// the bare IP is created as a /32 CIDR, and is not replaced
+				Config: `
+resource "ccx_firewall_rule" "vpn" {
+  datastore_id = "datastore-1"
+  source       = "192.168.0.5"
+  description  = "vpn"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ccx_firewall_rule.vpn", "id", "datastore-1/192.168.0.5/32"),
+					resource.TestCheckResourceAttr("ccx_firewall_rule.vpn", "source", "192.168.0.5/32"),
+					func(*terraform.State) error {
+						require.Equal(t, []ccx.FirewallRule{{Source: "192.168.0.5/32", Description: "vpn"}}, rules)
+						return nil
+					},
+				),
+			},
+			{
+				Config: `
+resource "ccx_firewall_rule" "vpn" {
+  datastore_id = "datastore-1"
+  source       = "192.168.0.5/"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid CIDR`),
+			},
+			{
+				Config: `
+resource "ccx_firewall_rule" "office" {
+  datastore_id = "datastore-1"
+  source       = "10.0.0.0/16"
+  description  = "head office"
+  ports        = ["postgres"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ccx_firewall_rule.office", "ports.#", "1"),
+					resource.TestCheckResourceAttr("ccx_firewall_rule.office", "ports.0", "postgres"),
+					func(*terraform.State) error {
+						require.Equal(t, []ccx.FirewallRule{{Source: "10.0.0.0/16", Description: "head office", Ports: []string{"postgres"}}}, rules)
+						return nil
+					},
+				),
+			},
+			{
+				// the rule is replaced by one opening all ports, which is kept on refresh
+				Config: `
+resource "ccx_firewall_rule" "office" {
+  datastore_id = "datastore-1"
+  source       = "10.0.0.0/16"
+  description  = "head office"
+  ports        = ["*"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ccx_firewall_rule.office", "ports.#", "1"),
+					resource.TestCheckResourceAttr("ccx_firewall_rule.office", "ports.0", "*"),
+					func(*terraform.State) error {
+						require.Equal(t, []ccx.FirewallRule{{Source: "10.0.0.0/16", Description: "head office", Ports: []string{ccx.AllPorts}}}, rules)
+						return nil
+					},
+				),
+			},
+		},
+	})
+
+	require.Empty(t, rules)
+}
+
+func Test_parseFirewallRuleID(t *testing.T) {
+	tests := []struct {
+		id      string
+		storeID string
+		source  string
+		wantErr bool
+	}{
+		{id: "datastore-1/10.0.0.0/16", storeID: "datastore-1", source: "10.0.0.0/16"},
+		{id: "datastore-1/2001:db8::/32", storeID: "datastore-1", source: "2001:db8::/32"},
+		{id: "datastore-1/10.0.0.1", storeID: "datastore-1", source: "10.0.0.1/32"},
+		{id: "datastore-1/10.1.2.3/16", storeID: "datastore-1", source: "10.1.0.0/16"},
+		{id: "datastore-1/10.0.0.256", wantErr: true},
+		{id: "datastore-1", wantErr: true},
+		{id: "/10.0.0.0/16", wantErr: true},
+		{id: "datastore-1/", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			storeID, source, err := parseFirewallRuleID(tt.id)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.storeID, storeID)
+			require.Equal(t, tt.source, source)
+		})
+	}
+}
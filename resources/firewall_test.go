@@ -91,6 +91,58 @@ func Test_firewallsSame(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "ports not set in ls2",
+			ls1: []ccx.FirewallRule{
+				{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"exporter", "postgres"}},
+			},
+			ls2: []ccx.FirewallRule{
+				{Source: "1.2.3.4/32", Description: "foo"},
+			},
+			want: true,
+		},
+		{
+			name: "same ports, different order",
+			ls1: []ccx.FirewallRule{
+				{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"exporter", "postgres"}},
+			},
+			ls2: []ccx.FirewallRule{
+				{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"postgres", "exporter"}},
+			},
+			want: true,
+		},
+		{
+			name: "different ports",
+			ls1: []ccx.FirewallRule{
+				{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"exporter", "postgres"}},
+			},
+			ls2: []ccx.FirewallRule{
+				{Source: "1.2.3.4/32", Description: "foo", Ports: []string{"postgres"}},
+			},
+			want: false,
+		},
+		{
+			name: "ip and cidr",
+			ls1: []ccx.FirewallRule{
+				{Source: "1.2.3.4/32", Description: "foo"},
+				{Source: "2001:db8::1/128", Description: "bar"},
+			},
+			ls2: []ccx.FirewallRule{
+				{Source: "1.2.3.4", Description: "foo"},
+				{Source: "2001:DB8::1", Description: "bar"},
+			},
+			want: true,
+		},
+		{
+			name: "different prefix length",
+			ls1: []ccx.FirewallRule{
+				{Source: "2001:db8::/32", Description: "foo"},
+			},
+			ls2: []ccx.FirewallRule{
+				{Source: "2001:db8::/48", Description: "foo"},
+			},
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,156 @@
+package resources
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// frameworkProvider serves the resources which have been migrated from the SDK, and what can only be built on the plugin framework, e.g. ephemeral resources and functions.
+// It is muxed with the SDK provider, so both share the provider configuration. A resource must only be served by one of them.
+type frameworkProvider struct {
+	configure          func(ctx context.Context, cfg providerConfig) error
+	resources          []func() resource.Resource
+	ephemeralResources []func() ephemeral.EphemeralResource
+	functions          []func() function.Function
+}
+
+type frameworkProviderModel struct {
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	BaseURL      types.String `tfsdk:"base_url"`
+	Timeout      types.String `tfsdk:"timeout"`
+}
+
+func FrameworkProvider() provider.Provider {
+	// like in Provider, services are set into the resources when configure is called
+	parameterGroup := &ParameterGroup{}
+	credentials := &DatastoreCredentials{}
+
+	configure := func(ctx context.Context, cfg providerConfig) error {
+		svc, err := newServices(cfg)
+		if err != nil {
+			return err
+		}
+
+		parameterGroup.svc = svc.parameterGroup
+		parameterGroup.contentSvc = svc.content
+
+		credentials.svc = svc.datastore
+
+		return nil
+	}
+
+	return makeFrameworkProvider(configure, parameterGroup, credentials)
+}
+
+func makeFrameworkProvider(configure func(ctx context.Context, cfg providerConfig) error, parameterGroup *ParameterGroup, credentials *DatastoreCredentials) *frameworkProvider {
+	return &frameworkProvider{
+		configure: configure,
+		resources: []func() resource.Resource{
+			func() resource.Resource { return parameterGroup },
+		},
+		ephemeralResources: []func() ephemeral.EphemeralResource{
+			func() ephemeral.EphemeralResource { return credentials },
+		},
+		functions: []func() function.Function{
+			func() function.Function { return ParseDsnFunction{} },
+			func() function.Function { return BuildDsnFunction{} },
+		},
+	}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "ccx"
+}
+
+// Schema must be the same as the schema of the SDK provider, otherwise muxing fails.
+// The SDK reports attributes which are required but have a default func as optional.
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"client_id": schema.StringAttribute{
+				Optional:    true,
+				Description: clientIDDescription,
+			},
+			"client_secret": schema.StringAttribute{
+				Optional:    true,
+				Description: clientSecretDescription,
+			},
+			"base_url": schema.StringAttribute{
+				Optional:    true,
+				Description: baseURLDescription,
+			},
+			"timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: timeoutDescription,
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var m frameworkProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cfg := providerConfig{
+		ClientID:     stringOrEnv(m.ClientID, "CCX_CLIENT_ID", ""),
+		ClientSecret: stringOrEnv(m.ClientSecret, "CCX_CLIENT_SECRET", ""),
+		BaseURL:      strings.Trim(stringOrEnv(m.BaseURL, "CCX_BASE_URL", defaultBaseURL), "/"),
+	}
+
+	timeout := stringOrEnv(m.Timeout, "CCX_TIMEOUT", defaultTimeout)
+
+	if t, err := time.ParseDuration(timeout); err == nil {
+		cfg.Timeout = t
+	} else {
+		resp.Diagnostics.AddError("invalid timeout", "invalid timeout ("+timeout+"): "+err.Error())
+		return
+	}
+
+	if err := p.configure(ctx, cfg); err != nil {
+		resp.Diagnostics.AddError("failed to configure provider", err.Error())
+	}
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return p.resources
+}
+
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return p.ephemeralResources
+}
+
+func (p *frameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return p.functions
+}
+
+// stringOrEnv returns the configured value, falling back to the environment variable and then to the default, like schema.EnvDefaultFunc
+func stringOrEnv(v types.String, env, def string) string {
+	if !v.IsNull() && !v.IsUnknown() {
+		return v.ValueString()
+	}
+
+	if s, ok := os.LookupEnv(env); ok {
+		return s
+	}
+
+	return def
+}
@@ -0,0 +1,150 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+)
+
+var dsnAttributeTypes = map[string]attr.Type{
+	"vendor":   types.StringType,
+	"host":     types.StringType,
+	"port":     types.Int64Type,
+	"username": types.StringType,
+	"password": types.StringType,
+	"dbname":   types.StringType,
+	"ssl":      types.BoolType,
+}
+
+type dsnModel struct {
+	Vendor   string `tfsdk:"vendor"`
+	Host     string `tfsdk:"host"`
+	Port     int64  `tfsdk:"port"`
+	Username string `tfsdk:"username"`
+	Password string `tfsdk:"password"`
+	DbName   string `tfsdk:"dbname"`
+	SSL      bool   `tfsdk:"ssl"`
+}
+
+// ParseDsnFunction is provider::ccx::parse_dsn, which splits a DSN of a datastore into its parts
+type ParseDsnFunction struct{}
+
+func (f ParseDsnFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_dsn"
+}
+
+func (f ParseDsnFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Split a DSN into its parts",
+		Description: "Splits a DSN, e.g. `primary_dsn` of a datastore, into vendor, host, port, username, password, dbname and ssl. All formats of the DSNs of datastores are supported, including the ADO.NET format of Microsoft SQL Server. The vendor is the format of the DSN, so `mysql` is returned for MySQL, MariaDB and Percona, and `redis` for Redis and Valkey.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "dsn",
+				Description: "DSN to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: dsnAttributeTypes,
+		},
+	}
+}
+
+func (f ParseDsnFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var dsn string
+
+	resp.Error = req.Arguments.Get(ctx, &dsn)
+	if resp.Error != nil {
+		return
+	}
+
+	info, err := ccx.ParseDsn(dsn)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, dsnModel{
+		Vendor:   info.Vendor,
+		Host:     info.Host,
+		Port:     int64(info.Port),
+		Username: info.Username,
+		Password: info.Password,
+		DbName:   info.DbName,
+		SSL:      info.SSL,
+	})
+}
+
+// BuildDsnFunction is provider::ccx::build_dsn, which builds a DSN in the same format as the DSNs of datastores
+type BuildDsnFunction struct{}
+
+func (f BuildDsnFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_dsn"
+}
+
+func (f BuildDsnFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a DSN from its parts",
+		Description: "Builds a DSN in the format of the vendor, like `primary_dsn` of a datastore, e.g. to connect as another user. Optionally, pass `true` after the database name to require TLS.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "vendor",
+				Description: "Database vendor, e.g. `postgres`, `mysql`, `mariadb`, `percona`, `redis`, `valkey` or `microsoft`.",
+			},
+			function.StringParameter{
+				Name:        "host",
+				Description: "Host name.",
+			},
+			function.Int64Parameter{
+				Name:        "port",
+				Description: "Port, used when host does not include a port.",
+			},
+			function.StringParameter{
+				Name:        "username",
+				Description: "Username.",
+			},
+			function.StringParameter{
+				Name:        "password",
+				Description: "Password.",
+			},
+			function.StringParameter{
+				Name:        "dbname",
+				Description: "Name of the database.",
+			},
+		},
+		VariadicParameter: function.BoolParameter{
+			Name:        "ssl",
+			Description: "Whether the DSN requires TLS, false when omitted.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f BuildDsnFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		m   dsnModel
+		ssl []bool
+	)
+
+	resp.Error = req.Arguments.Get(ctx, &m.Vendor, &m.Host, &m.Port, &m.Username, &m.Password, &m.DbName, &ssl)
+	if resp.Error != nil {
+		return
+	}
+
+	if len(ssl) > 1 {
+		resp.Error = function.NewArgumentFuncError(6, "ssl must be passed at most once")
+		return
+	}
+
+	m.SSL = len(ssl) == 1 && ssl[0]
+
+	dsn := ccx.Dsn(vendorFromAlias(m.Vendor), m.Host, int(m.Port), m.Username, m.Password, m.DbName, m.SSL)
+	if dsn == "" {
+		resp.Error = function.NewArgumentFuncError(0, "unsupported vendor "+m.Vendor)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, dsn)
+}
@@ -0,0 +1,436 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+	"golang.org/x/sync/errgroup"
+)
+
+const ipSetDoc = `
+An IP set is a named list of CIDRs, e.g. the office, VPN or CI egress ranges, which is attached to many datastores. A firewall rule is created on each datastore for each CIDR, and changing the set updates all the datastores.
+
+Reference the set in the ` + "`firewall`" + ` of each datastore with ` + "`ip_set`" + `, or set ` + "`manage_firewall = false`" + ` on the datastore, otherwise the datastore removes the rules of the set.`
+
+// ipSetConcurrency bounds the datastores updated at once, like deleting firewall rules
+const ipSetConcurrency = 10
+
+func ipSetDescription(name string) string {
+	return ccx.IPSetRulePrefix + name
+}
+
+// ipSetName returns the name of the ip set the rule belongs to
+func ipSetName(f ccx.FirewallRule) (string, bool) {
+	return f.IPSet()
+}
+
+type IPSet struct {
+	svc ccx.DatastoresService
+}
+
+func (r *IPSet) Schema() *schema.Resource {
+	return &schema.Resource{
+		Description: ipSetDoc,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the IP set, which is referenced by `ip_set` in the `firewall` of datastores.",
+			},
+			"cidrs": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "CIDRs of the IP set. IPv4 and IPv6 are supported, a single IP is converted to a /32 or /128 CIDR.",
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateCIDR,
+				},
+			},
+			"ports": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Description: "Names of the services, e.g. the database, which the CIDRs have access to. `[\"*\"]` opens all ports, e.g. to open all ports again after setting services. When not set, all ports are open for new rules, and the ports are read from CCX.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"datastore_ids": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "IDs of the datastores the IP set is attached to.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		CustomizeDiff: validatePortsDiff,
+		CreateContext: r.Create,
+		ReadContext:   r.Read,
+		UpdateContext: r.Update,
+		DeleteContext: r.Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: r.Import,
+		},
+	}
+}
+
+func (r *IPSet) Create(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	name := getString(d, "name")
+	rules := ipSetRulesFromSchema(d)
+
+	d.SetId(name)
+
+	attached, err := r.attach(ctx, name, getStrings(d, "datastore_ids"), rules)
+
+	// only the datastores which have the rules are stored, so that the next apply retries the others
+	if err := setStrings(d, "datastore_ids", attached); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err != nil {
+		return diag.Errorf("attaching ip set %q completed only partially: %s", name, err)
+	}
+
+	return r.Read(ctx, d, nil)
+}
+
+// Read keeps the datastores which have all the rules of the ip set, so that datastores which lost rules are updated again
+func (r *IPSet) Read(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	rules := ipSetMatchRules(d)
+
+	var (
+		mu     sync.Mutex
+		synced []string
+		ports  []string
+	)
+
+	err := forEachDatastore(ctx, getStrings(d, "datastore_ids"), func(ctx context.Context, storeID string) error {
+		have, err := r.svc.GetFirewallRules(ctx, storeID)
+		if errors.Is(err, ccx.ErrResourceNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if found := ipSetRules(have, getString(d, "name")); firewallsSame(found, rules) {
+			mu.Lock()
+			synced = append(synced, storeID)
+			if len(found) != 0 {
+				ports = found[0].Ports
+			}
+			mu.Unlock()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	slices.Sort(synced)
+
+	if err := setStrings(d, "datastore_ids", synced); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the ports are read back sorted, so the order in the configuration is kept when they are the same,
+	// and CCX lists the ports of the datastore for rules opening all ports
+	if len(synced) == 0 || slices.Equal(ports, slices.Sorted(slices.Values(getStrings(d, "ports")))) || slices.Equal(getStrings(d, "ports"), []string{ccx.AllPorts}) {
+		ports = getStrings(d, "ports")
+	}
+
+	return diag.FromErr(setStrings(d, "ports", ports))
+}
+
+func (r *IPSet) Update(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	name := getString(d, "name")
+	rules := ipSetMatchRules(d)
+
+	o, n := d.GetChange("datastore_ids")
+	oldIDs, newIDs := setToStrings(o), setToStrings(n)
+
+	var detached []string
+
+	for _, id := range oldIDs {
+		if !slices.Contains(newIDs, id) {
+			detached = append(detached, id)
+		}
+	}
+
+	attached, attachErr := r.attach(ctx, name, newIDs, rules)
+
+	notDetached, detachErr := r.detach(ctx, name, detached)
+
+	if err := setStrings(d, "datastore_ids", append(attached, notDetached...)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := errors.Join(attachErr, detachErr); err != nil {
+		return diag.Errorf("updating ip set %q completed only partially: %s", name, err)
+	}
+
+	return nil
+}
+
+func (r *IPSet) Delete(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	name := getString(d, "name")
+
+	notDetached, err := r.detach(ctx, name, getStrings(d, "datastore_ids"))
+	if err != nil {
+		if err := setStrings(d, "datastore_ids", notDetached); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return diag.Errorf("deleting ip set %q completed only partially: %s", name, err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// Import an ip set by name, the datastores and CIDRs are read from the firewall rules of all datastores
+func (r *IPSet) Import(ctx context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+	name := d.Id()
+
+	ls, err := r.svc.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing datastores: %w", err)
+	}
+
+	ids := make([]string, 0, len(ls))
+	for _, c := range ls {
+		ids = append(ids, c.ID)
+	}
+
+	var (
+		mu         sync.Mutex
+		datastores []string
+		rules      []ccx.FirewallRule
+	)
+
+	err = forEachDatastore(ctx, ids, func(ctx context.Context, storeID string) error {
+		have, err := r.svc.GetFirewallRules(ctx, storeID)
+		if err != nil {
+			return err
+		}
+
+		if found := ipSetRules(have, name); len(found) != 0 {
+			mu.Lock()
+			datastores = append(datastores, storeID)
+			rules = found
+			mu.Unlock()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(datastores) == 0 {
+		return nil, fmt.Errorf("ip set %q not found on any datastore", name)
+	}
+
+	slices.Sort(datastores)
+
+	cidrs := make([]string, 0, len(rules))
+	for _, f := range rules {
+		cidrs = append(cidrs, f.Source)
+	}
+
+	if err := d.Set("name", name); err != nil {
+		return nil, err
+	}
+
+	if err := setStrings(d, "cidrs", cidrs); err != nil {
+		return nil, err
+	}
+
+	if err := setStrings(d, "ports", rules[0].Ports); err != nil {
+		return nil, err
+	}
+
+	if err := setStrings(d, "datastore_ids", datastores); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// attach sets the rules of the ip set on the datastores, and returns the datastores where it succeeded
+func (r *IPSet) attach(ctx context.Context, name string, storeIDs []string, rules []ccx.FirewallRule) ([]string, error) {
+	var (
+		mu       sync.Mutex
+		attached []string
+	)
+
+	err := forEachDatastore(ctx, storeIDs, func(ctx context.Context, storeID string) error {
+		if err := r.setRules(ctx, storeID, name, rules); err != nil {
+			return err
+		}
+
+		mu.Lock()
+		attached = append(attached, storeID)
+		mu.Unlock()
+
+		return nil
+	})
+
+	slices.Sort(attached)
+
+	return attached, err
+}
+
+// detach removes the rules of the ip set from the datastores, and returns the datastores where it failed
+func (r *IPSet) detach(ctx context.Context, name string, storeIDs []string) ([]string, error) {
+	var (
+		mu     sync.Mutex
+		failed []string
+	)
+
+	err := forEachDatastore(ctx, storeIDs, func(ctx context.Context, storeID string) error {
+		err := r.setRules(ctx, storeID, name, nil)
+		if errors.Is(err, ccx.ErrResourceNotFound) {
+			return nil // the rules were deleted with the datastore
+		} else if err != nil {
+			mu.Lock()
+			failed = append(failed, storeID)
+			mu.Unlock()
+		}
+
+		return err
+	})
+
+	slices.Sort(failed)
+
+	return failed, err
+}
+
+// setRules replaces the rules of the ip set on the datastore, only the rules of the ip set are changed,
+// so that ip sets attached to the same datastore can be changed concurrently
+func (r *IPSet) setRules(ctx context.Context, storeID, name string, rules []ccx.FirewallRule) error {
+	return r.svc.SetIPSetFirewallRules(ctx, storeID, name, rules)
+}
+
+// forEachDatastore calls fn for each datastore, with bounded concurrency, a failure does not cancel the others
+func forEachDatastore(ctx context.Context, storeIDs []string, fn func(ctx context.Context, storeID string) error) error {
+	errs := make([]error, len(storeIDs))
+
+	var eg errgroup.Group
+
+	eg.SetLimit(ipSetConcurrency)
+
+	for i, id := range storeIDs {
+		eg.Go(func() error {
+			if err := fn(ctx, id); err != nil {
+				errs[i] = fmt.Errorf("datastore %s: %w", id, err)
+			}
+
+			return nil
+		})
+	}
+
+	_ = eg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// ipSetRules returns the rules of the ip set
+func ipSetRules(rules []ccx.FirewallRule, name string) []ccx.FirewallRule {
+	var ls []ccx.FirewallRule
+
+	for _, f := range rules {
+		if n, ok := ipSetName(f); ok && n == name && f.Source != "" {
+			ls = append(ls, f)
+		}
+	}
+
+	return ls
+}
+
+func ipSetRulesFromSchema(d *schema.ResourceData) []ccx.FirewallRule {
+	name := getString(d, "name")
+	ports := getStrings(d, "ports")
+
+	var rules []ccx.FirewallRule
+
+	for _, s := range getStrings(d, "cidrs") {
+		f := ccx.FirewallRule{
+			Description: ipSetDescription(name),
+		}
+
+		// the cidrs are validated at plan time
+		if c, err := ccx.CanonicalCIDR(s); err == nil {
+			f.Source = c
+		} else {
+			f.Source = s
+		}
+
+		if len(ports) != 0 {
+			f.Ports = slices.Sorted(slices.Values(ports))
+		}
+
+		rules = append(rules, f)
+	}
+
+	slices.SortStableFunc(rules, func(a, b ccx.FirewallRule) int {
+		return strings.Compare(a.Source, b.Source)
+	})
+
+	return rules
+}
+
+// ipSetMatchRules returns the rules of the ip set to compare with the rules in CCX, which lists the ports of the datastore for rules
+// opening all ports, so the ports are cleared when the ip set opened all ports before the change too
+func ipSetMatchRules(d *schema.ResourceData) []ccx.FirewallRule {
+	rules := ipSetRulesFromSchema(d)
+
+	if old, _ := d.GetChange("ports"); !slices.Equal(listToStrings(old), []string{ccx.AllPorts}) {
+		return rules
+	}
+
+	for i, f := range rules {
+		if f.OpensAllPorts() {
+			rules[i].Ports = nil
+		}
+	}
+
+	return rules
+}
+
+func setToStrings(v any) []string {
+	s, ok := v.(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	var ls []string
+
+	for _, e := range s.List() {
+		if str, ok := e.(string); ok {
+			ls = append(ls, str)
+		}
+	}
+
+	return ls
+}
+
+func listToStrings(v any) []string {
+	ls, _ := v.([]any)
+
+	s := make([]string, 0, len(ls))
+
+	for _, e := range ls {
+		if str, ok := e.(string); ok {
+			s = append(s, str)
+		}
+	}
+
+	return s
+}
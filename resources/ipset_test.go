@@ -0,0 +1,259 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// firewallStore holds the firewall rules of datastores, for mocking GetFirewallRules, SetFirewallRules and SetIPSetFirewallRules
+type firewallStore struct {
+	mu    sync.Mutex
+	rules map[string][]ccx.FirewallRule
+}
+
+func (s *firewallStore) expect(m mockServices) {
+	m.datastore.EXPECT().GetFirewallRules(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, id string) ([]ccx.FirewallRule, error) {
+		return s.get(id)
+	}).Maybe()
+
+	m.datastore.EXPECT().SetFirewallRules(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, id string, rules []ccx.FirewallRule) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.rules[id]; !ok {
+			return ccx.ErrResourceNotFound
+		}
+
+		s.rules[id] = slices.Clone(rules)
+
+		return nil
+	}).Maybe()
+
+	m.datastore.EXPECT().SetIPSetFirewallRules(mock.Anything, mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, id, name string, rules []ccx.FirewallRule) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		have, ok := s.rules[id]
+		if !ok {
+			return ccx.ErrResourceNotFound
+		}
+
+		s.rules[id] = append(slices.DeleteFunc(slices.Clone(have), func(f ccx.FirewallRule) bool {
+			n, ok := f.IPSet()
+			return ok && n == name
+		}), rules...)
+
+		return nil
+	}).Maybe()
+}
+
+func (s *firewallStore) get(id string) ([]ccx.FirewallRule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rules, ok := s.rules[id]
+	if !ok {
+		return nil, ccx.ErrResourceNotFound
+	}
+
+	return slices.Clone(rules), nil
+}
+
+func (s *firewallStore) sources(id string) []string {
+	rules, _ := s.get(id)
+
+	var ls []string
+	for _, f := range rules {
+		ls = append(ls, f.Source+" "+f.Description)
+	}
+
+	slices.Sort(ls)
+
+	return ls
+}
+
+func TestIPSet(t *testing.T) {
+	m, p := mockProvider(t)
+
+	store := &firewallStore{
+		rules: map[string][]ccx.FirewallRule{
+			"datastore-1": {{Source: "172.16.0.0/16", Description: "app"}},
+			"datastore-2": nil,
+		},
+	}
+
+	store.expect(m)
+
+	m.datastore.EXPECT().List(mock.Anything).Return([]ccx.Datastore{{ID: "datastore-1"}, {ID: "datastore-2"}}, nil)
+
+	config := `
+resource "ccx_ip_set" "office" {
+  name          = "office"
+  cidrs         = [%s]
+  datastore_ids = [%s]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"ccx": func() (*schema.Provider, error) {
+				return p, nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, `"10.0.0.5", "192.168.0.0/24"`, `"datastore-1", "datastore-2"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ccx_ip_set.office", "id", "office"),
+					resource.TestCheckResourceAttr("ccx_ip_set.office", "datastore_ids.#", "2"),
+					func(*terraform.State) error {
+						require.Equal(t, []string{"10.0.0.5/32 ccx_ip_set:office", "172.16.0.0/16 app", "192.168.0.0/24 ccx_ip_set:office"}, store.sources("datastore-1"))
+						require.Equal(t, []string{"10.0.0.5/32 ccx_ip_set:office", "192.168.0.0/24 ccx_ip_set:office"}, store.sources("datastore-2"))
+						return nil
+					},
+				),
+			},
+			{
+				// changing the set updates all datastores
+				Config: fmt.Sprintf(config, `"10.0.0.5/32", "10.1.0.0/16"`, `"datastore-1", "datastore-2"`),
+				Check: func(*terraform.State) error {
+					require.Equal(t, []string{"10.0.0.5/32 ccx_ip_set:office", "10.1.0.0/16 ccx_ip_set:office", "172.16.0.0/16 app"}, store.sources("datastore-1"))
+					require.Equal(t, []string{"10.0.0.5/32 ccx_ip_set:office", "10.1.0.0/16 ccx_ip_set:office"}, store.sources("datastore-2"))
+					return nil
+				},
+			},
+			{
+				// a datastore which lost rules of the set is updated again
+				PreConfig: func() {
+					store.mu.Lock()
+					store.rules["datastore-2"] = store.rules["datastore-2"][:1]
+					store.mu.Unlock()
+				},
+				Config: fmt.Sprintf(config, `"10.0.0.5/32", "10.1.0.0/16"`, `"datastore-1", "datastore-2"`),
+				Check: func(*terraform.State) error {
+					require.Equal(t, []string{"10.0.0.5/32 ccx_ip_set:office", "10.1.0.0/16 ccx_ip_set:office"}, store.sources("datastore-2"))
+					return nil
+				},
+			},
+			{
+				ResourceName:      "ccx_ip_set.office",
+				ImportState:       true,
+				ImportStateId:     "office",
+				ImportStateVerify: true,
+			},
+			{
+				// detaching a datastore removes the rules of the set from it
+				Config: fmt.Sprintf(config, `"10.0.0.5/32", "10.1.0.0/16"`, `"datastore-1"`),
+				Check: func(*terraform.State) error {
+					require.Empty(t, store.sources("datastore-2"))
+					return nil
+				},
+			},
+		},
+	})
+
+	require.Equal(t, []string{"172.16.0.0/16 app"}, store.sources("datastore-1"))
+	require.Empty(t, store.sources("datastore-2"))
+}
+
+func TestIPSet_SameDatastore(t *testing.T) {
+	m, p := mockProvider(t)
+
+	store := &firewallStore{
+		rules: map[string][]ccx.FirewallRule{
+			"datastore-1": {{Source: "172.16.0.0/16", Description: "app"}},
+		},
+	}
+
+	store.expect(m)
+
+	// both sets are changed at the same time, each only changes its own rules
+	config := `
+resource "ccx_ip_set" "office" {
+  name          = "office"
+  cidrs         = [%s]
+  datastore_ids = ["datastore-1"]
+}
+
+resource "ccx_ip_set" "vpn" {
+  name          = "vpn"
+  cidrs         = [%s]
+  datastore_ids = ["datastore-1"]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"ccx": func() (*schema.Provider, error) {
+				return p, nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, `"10.0.0.0/16"`, `"192.168.0.0/24"`),
+				Check: func(*terraform.State) error {
+					require.Equal(t, []string{"10.0.0.0/16 ccx_ip_set:office", "172.16.0.0/16 app", "192.168.0.0/24 ccx_ip_set:vpn"}, store.sources("datastore-1"))
+					return nil
+				},
+			},
+			{
+				Config: fmt.Sprintf(config, `"10.1.0.0/16"`, `"192.168.1.0/24"`),
+				Check: func(*terraform.State) error {
+					require.Equal(t, []string{"10.1.0.0/16 ccx_ip_set:office", "172.16.0.0/16 app", "192.168.1.0/24 ccx_ip_set:vpn"}, store.sources("datastore-1"))
+					return nil
+				},
+			},
+		},
+	})
+
+	require.Equal(t, []string{"172.16.0.0/16 app"}, store.sources("datastore-1"))
+}
+
+func Test_forEachDatastore(t *testing.T) {
+	var ids []string
+	for i := range 3 * ipSetConcurrency {
+		ids = append(ids, fmt.Sprintf("datastore-%d", i))
+	}
+
+	var running, maxRunning, calls atomic.Int32
+
+	err := forEachDatastore(context.Background(), ids, func(_ context.Context, id string) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+
+		if id == "datastore-3" {
+			return fmt.Errorf("api error")
+		}
+
+		return nil
+	})
+
+	// a failure does not cancel the others
+	require.EqualError(t, err, "datastore datastore-3: api error")
+	require.Equal(t, int32(len(ids)), calls.Load())
+	require.LessOrEqual(t, maxRunning.Load(), int32(ipSetConcurrency))
+}
@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+)
+
+type addNode struct{}
+
+func (a addNode) Schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"az": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Availability zone of the node to add.",
+			},
+			"instance_size": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Instance size of the node to add. Defaults to the instance_size of the datastore.",
+			},
+		},
+	}
+}
+
+type node struct{}
+
+func (n node) Schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the node.",
+			},
+			"az": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Availability zone of the node.",
+			},
+			"instance_size": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Instance size of the node.",
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Role of the node, e.g. primary or replica.",
+			},
+		},
+	}
+}
+
+func getAddNodes(d *schema.ResourceData) []ccx.NodeSpec {
+	raw, ok := d.Get("add_node").([]any)
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+
+	ls := make([]ccx.NodeSpec, 0, len(raw))
+
+	for _, r := range raw {
+		m, ok := r.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		var n ccx.NodeSpec
+
+		if v, ok := m["az"].(string); ok {
+			n.AZ = v
+		}
+
+		if v, ok := m["instance_size"].(string); ok {
+			n.InstanceSize = v
+		}
+
+		ls = append(ls, n)
+	}
+
+	return ls
+}
+
+// setNodes sets the nodes of the datastore, in the order they were created
+func setNodes(d *schema.ResourceData, hosts []ccx.Host) error {
+	hosts = slices.Clone(hosts)
+
+	slices.SortStableFunc(hosts, func(a, b ccx.Host) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	ls := make([]any, 0, len(hosts))
+
+	for _, h := range hosts {
+		ls = append(ls, map[string]any{
+			"id":            h.ID,
+			"az":            h.AZ,
+			"instance_size": h.InstanceType,
+			"role":          h.Role,
+		})
+	}
+
+	return d.Set("nodes", ls)
+}
@@ -3,235 +3,232 @@ package resources
 import (
 	"context"
 	"errors"
-	"fmt"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
 )
 
 const pgDoc = `
 Parameter groups are a CCX resource, and contain a values for configurable settings with the database system. For documentation about which settings are available for each database system, log into a CCX instance and select to create a parameter group. All options and their default values will be shown.`
 
+// ParameterGroup is served by the framework provider.
+// Its schema is compatible with the state written by the SDK version of this resource.
 type ParameterGroup struct {
 	svc        ccx.ParameterGroupsService
 	contentSvc ccx.ContentService
 }
 
-func (r *ParameterGroup) Schema() *schema.Resource {
-	return &schema.Resource{
+type parameterGroupModel struct {
+	ID              types.String          `tfsdk:"id"`
+	Name            types.String          `tfsdk:"name"`
+	DatabaseVendor  equivalentStringValue `tfsdk:"database_vendor"`
+	DatabaseVersion equivalentStringValue `tfsdk:"database_version"`
+	DatabaseType    equivalentStringValue `tfsdk:"database_type"`
+	Description     types.String          `tfsdk:"description"`
+	Parameters      map[string]string     `tfsdk:"parameters"`
+}
+
+func (r *ParameterGroup) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parameter_group"
+}
+
+func (r *ParameterGroup) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		Description: pgDoc,
-		Schema: map[string]*schema.Schema{
-			"id": {
-				Type:     schema.TypeString,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"name": {
-				Type:        schema.TypeString,
+			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "Name of this parameter group. This is just for your reference, and can be changed later.",
 			},
-			"database_vendor": {
-				Type:             schema.TypeString,
-				Required:         true,
-				Description:      "Database vendor for which this parameter group is applicable - to assign a parameter group to a datastore, they group and store must have the same vendor. Allowed values depend on the CCX instance. Commonly available vendors are `mysql` and `postgres`.",
-				DiffSuppressFunc: vendorSuppressor,
+			"database_vendor": schema.StringAttribute{
+				Required:   true,
+				CustomType: equivalentStringType{equivalence: vendorAlias},
+				PlanModifiers: []planmodifier.String{
+					useEquivalentState{equivalence: vendorAlias},
+				},
+				Description: "Database vendor for which this parameter group is applicable - to assign a parameter group to a datastore, they group and store must have the same vendor. Allowed values depend on the CCX instance. Commonly available vendors are `mysql` and `postgres`.",
 			},
-			"database_version": {
-				Type:             schema.TypeString,
-				Required:         true,
-				Description:      "Database version for which this parameter group is applicable.",
-				DiffSuppressFunc: caseInsensitiveSuppressor,
+			"database_version": schema.StringAttribute{
+				Required:   true,
+				CustomType: equivalentStringType{equivalence: caseInsensitive},
+				PlanModifiers: []planmodifier.String{
+					useEquivalentState{equivalence: caseInsensitive},
+				},
+				Description: "Database version for which this parameter group is applicable.",
 			},
-			"database_type": {
-				Type:             schema.TypeString,
-				Required:         true,
-				Description:      "Database type for which this parameter group is applicable.",
-				DiffSuppressFunc: caseInsensitiveSuppressor,
+			"database_type": schema.StringAttribute{
+				Required:   true,
+				CustomType: equivalentStringType{equivalence: caseInsensitive},
+				PlanModifiers: []planmodifier.String{
+					useEquivalentState{equivalence: caseInsensitive},
+				},
+				Description: "Database type for which this parameter group is applicable.",
 			},
-			"description": {
-				Type:        schema.TypeString,
+			"description": schema.StringAttribute{
 				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""), // the SDK stored an empty string when not set
 				Description: "Description of this parameter group. This is just for your reference, and can be changed later.",
 			},
-			"parameters": {
-				Type:        schema.TypeMap,
+			"parameters": schema.MapAttribute{
 				Required:    true,
+				ElementType: types.StringType,
 				Description: "Parameters for this parameter group.",
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
 			},
 		},
-		CreateContext: r.Create,
-		ReadContext:   r.Read,
-		UpdateContext: r.Update,
-		DeleteContext: r.Delete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
 	}
 }
 
-func (r *ParameterGroup) Create(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
-	p, err := parameterGroupFromSchema(d)
-	if err != nil {
-		return diag.FromErr(err)
+func (r *ParameterGroup) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var m parameterGroupModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	p := parameterGroupFromModel(m)
+
 	vendors, err := r.contentSvc.DBVendors(ctx)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("loading db vendor information: %w", err))
+		resp.Diagnostics.AddError("loading db vendor information", err.Error())
+		return
 	}
 
 	if err := validateDB(vendors, p.DatabaseVendor, p.DatabaseVersion, p.DatabaseType); err != nil {
-		return diag.FromErr(fmt.Errorf("validating db vendor: %w", err))
+		resp.Diagnostics.AddError("validating db vendor", err.Error())
+		return
 	}
 
 	n, err := r.svc.Create(ctx, p)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("creating parameter group", err.Error())
+		return
 	}
 
-	if err := fillSchemaFromParameterGroup(*n, d); err != nil {
-		return diag.FromErr(err)
-	}
+	fillModelFromParameterGroup(*n, &m)
 
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, &m)...)
 }
 
-func (r *ParameterGroup) Read(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
-	id := d.Id()
+func (r *ParameterGroup) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var m parameterGroupModel
 
-	p, err := r.svc.Read(ctx, id)
+	resp.Diagnostics.Append(req.State.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	p, err := r.svc.Read(ctx, m.ID.ValueString())
 	if errors.Is(err, ccx.ErrResourceNotFound) {
-		d.SetId("")
-		return nil
+		resp.State.RemoveResource(ctx)
+		return
 	} else if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("reading parameter group", err.Error())
+		return
 	}
 
-	if err := fillSchemaFromParameterGroup(*p, d); err != nil {
-		return diag.FromErr(err)
-	}
+	fillModelFromParameterGroup(*p, &m)
 
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, &m)...)
 }
 
-func (r *ParameterGroup) Update(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
-	id := d.Id()
+func (r *ParameterGroup) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var m, old parameterGroupModel
 
-	c, err := parameterGroupFromSchema(d)
-	if err != nil {
-		return diag.FromErr(err)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &m)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &old)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if d.HasChanges("database_vendor", "database_version", "database_type") {
-		return diag.FromErr(errors.New("database_vendor, database_version, database_type update is not supported"))
+	if !m.DatabaseVendor.equivalentTo(old.DatabaseVendor) || !m.DatabaseVersion.equivalentTo(old.DatabaseVersion) || !m.DatabaseType.equivalentTo(old.DatabaseType) {
+		resp.Diagnostics.AddError("updating parameter group", "database_vendor, database_version, database_type update is not supported")
+		return
 	}
 
-	if err := r.svc.Update(ctx, c); err != nil {
-		return diag.FromErr(err)
+	// only the spelling of an attribute changed, e.g. in state written by the SDK version of this resource
+	if m.Name.Equal(old.Name) && m.Description.Equal(old.Description) && maps.Equal(m.Parameters, old.Parameters) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &m)...)
+		return
 	}
 
-	p, err := r.svc.Read(ctx, id)
-	if err != nil {
-		return diag.FromErr(err)
+	if err := r.svc.Update(ctx, parameterGroupFromModel(m)); err != nil {
+		resp.Diagnostics.AddError("updating parameter group", err.Error())
+		return
 	}
 
-	if err := fillSchemaFromParameterGroup(*p, d); err != nil {
-		return diag.FromErr(err)
+	p, err := r.svc.Read(ctx, m.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("reading parameter group", err.Error())
+		return
 	}
 
-	return nil
-}
-
-func (r *ParameterGroup) Delete(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
-	id := d.Id()
+	fillModelFromParameterGroup(*p, &m)
 
-	if err := r.svc.Delete(ctx, id); err != nil {
-		return diag.FromErr(err)
-	}
-
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, &m)...)
 }
 
-func fillSchemaFromParameterGroup(p ccx.ParameterGroup, d *schema.ResourceData) error {
-	d.SetId(p.ID)
-
-	if err := d.Set("name", p.Name); err != nil {
-		return fmt.Errorf("setting name: %w", err)
-	}
-
-	if err := d.Set("database_vendor", p.DatabaseVendor); err != nil {
-		return fmt.Errorf("setting database_vendor: %w", err)
-	}
-
-	if err := d.Set("database_version", p.DatabaseVersion); err != nil {
-		return fmt.Errorf("setting database_version: %w", err)
-	}
-
-	if err := d.Set("database_type", p.DatabaseType); err != nil {
-		return fmt.Errorf("setting database_type: %w", err)
-	}
+func (r *ParameterGroup) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var m parameterGroupModel
 
-	if err := setDbParameters(d, p.DbParameters); err != nil {
-		return fmt.Errorf("setting parameters: %w", err)
+	resp.Diagnostics.Append(req.State.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if err := d.Set("description", p.Description); err != nil {
-		return fmt.Errorf("setting description: %w", err)
+	if err := r.svc.Delete(ctx, m.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("deleting parameter group", err.Error())
 	}
-
-	return nil
 }
 
-func parameterGroupFromSchema(d *schema.ResourceData) (ccx.ParameterGroup, error) {
-	g := ccx.ParameterGroup{
-		ID:              d.Id(),
-		Name:            getString(d, "name"),
-		DatabaseVendor:  getString(d, "database_vendor"),
-		DatabaseVersion: getString(d, "database_version"),
-		DatabaseType:    getString(d, "database_type"),
-		Description:     getString(d, "description"),
-	}
-
-	g.DatabaseVendor = vendorFromAlias(g.DatabaseVendor)
-
-	if v, err := getDbParameters(d); err == nil {
-		g.DbParameters = v
-	} else {
-		return ccx.ParameterGroup{}, err
-	}
-
-	return g, nil
+func (r *ParameterGroup) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func getDbParameters(d *schema.ResourceData) (map[string]string, error) {
-	parameters := make(map[string]string)
-
-	if raw, ok := d.GetOk("parameters"); ok {
-		for key, value := range raw.(map[string]any) {
-			if strValue, ok := value.(string); ok {
-				parameters[key] = strValue
-			} else {
-				return nil, fmt.Errorf("expected string for key %q in parameters, got %T", key, value)
-			}
-		}
+// fillModelFromParameterGroup sets the values read from CCX into the model
+// equivalent spellings of vendor, version and type are kept as configured by semantic equality,
+// and the spellings in state are kept when planning by useEquivalentState
+func fillModelFromParameterGroup(p ccx.ParameterGroup, m *parameterGroupModel) {
+	m.ID = types.StringValue(p.ID)
+	m.Name = types.StringValue(p.Name)
+	m.DatabaseVendor = newEquivalentString(p.DatabaseVendor, vendorAlias)
+	m.DatabaseVersion = newEquivalentString(p.DatabaseVersion, caseInsensitive)
+	m.DatabaseType = newEquivalentString(p.DatabaseType, caseInsensitive)
+	m.Description = types.StringValue(p.Description)
+	m.Parameters = maps.Clone(p.DbParameters)
+
+	if m.Parameters == nil {
+		m.Parameters = map[string]string{}
 	}
-
-	return parameters, nil
 }
 
-func setDbParameters(d *schema.ResourceData, ls map[string]string) error {
-	parameters := make(map[string]any, len(ls))
-	for key, value := range ls {
-		parameters[key] = value
+func parameterGroupFromModel(m parameterGroupModel) ccx.ParameterGroup {
+	return ccx.ParameterGroup{
+		ID:              m.ID.ValueString(),
+		Name:            m.Name.ValueString(),
+		DatabaseVendor:  vendorFromAlias(m.DatabaseVendor.ValueString()),
+		DatabaseVersion: m.DatabaseVersion.ValueString(),
+		DatabaseType:    m.DatabaseType.ValueString(),
+		Description:     m.Description.ValueString(),
+		DbParameters:    maps.Clone(m.Parameters),
 	}
-
-	if err := d.Set("parameters", parameters); err != nil {
-		return fmt.Errorf("error setting parameters: %w", err)
-	}
-
-	return nil
 }
+
+var (
+	_ resource.Resource                = &ParameterGroup{}
+	_ resource.ResourceWithImportState = &ParameterGroup{}
+)
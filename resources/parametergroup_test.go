@@ -1,17 +1,21 @@
 package resources
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParameterGroup_Create(t *testing.T) {
 	t.Run("basic", func(t *testing.T) {
-		m, p := mockProvider(t)
+		m, factories := mockProtoV5Provider(t)
 
 		pgCreated := ccx.ParameterGroup{
 			ID:              "parameter-group-id",
@@ -55,11 +59,7 @@ func TestParameterGroup_Create(t *testing.T) {
 			IsUnitTest: true,
 			PreCheck: func() {
 			},
-			ProviderFactories: map[string]func() (*schema.Provider, error){
-				"ccx": func() (*schema.Provider, error) {
-					return p, nil
-				},
-			},
+			ProtoV5ProviderFactories: factories,
 			Steps: []resource.TestStep{
 				{
 					Config: `
@@ -90,3 +90,214 @@ resource "ccx_parameter_group" "asteroid" {
 		m.AssertExpectations(t)
 	})
 }
+
+// sdkParameterGroupState is the state of a parameter group, as written by the SDK version of ccx_parameter_group,
+// with the vendor, version and type as named by CCX
+const sdkParameterGroupState = `{
+  "database_type": %q,
+  "database_vendor": %q,
+  "database_version": %q,
+  "description": "",
+  "id": "parameter-group-id",
+  "name": "asteroid",
+  "parameters": {
+    "max_connections": "100"
+  }
+}`
+
+func TestParameterGroup_StateCompatibility(t *testing.T) {
+	tests := []struct {
+		name       string
+		stored     ccx.ParameterGroup // as named by CCX and stored by the SDK
+		configured ccx.ParameterGroup
+	}{
+		{
+			name:       "as configured",
+			stored:     ccx.ParameterGroup{DatabaseVendor: "mariadb", DatabaseVersion: "10.11", DatabaseType: "galera"},
+			configured: ccx.ParameterGroup{DatabaseVendor: "mariadb", DatabaseVersion: "10.11", DatabaseType: "galera"},
+		},
+		{
+			name:       "aliased vendor",
+			stored:     ccx.ParameterGroup{DatabaseVendor: "percona", DatabaseVersion: "8", DatabaseType: "replication"},
+			configured: ccx.ParameterGroup{DatabaseVendor: "mysql", DatabaseVersion: "8", DatabaseType: "Replication"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testParameterGroupStateCompatibility(t, tt.stored, tt.configured)
+		})
+	}
+}
+
+func testParameterGroupStateCompatibility(t *testing.T, stored, applied ccx.ParameterGroup) {
+	ctx := context.Background()
+
+	m, factories := mockProtoV5Provider(t)
+
+	m.parameterGroup.EXPECT().Read(mock.Anything, "parameter-group-id").Return(&ccx.ParameterGroup{
+		ID:              "parameter-group-id",
+		Name:            "asteroid",
+		DatabaseVendor:  stored.DatabaseVendor,
+		DatabaseVersion: stored.DatabaseVersion,
+		DatabaseType:    stored.DatabaseType,
+		DbParameters: map[string]string{
+			"max_connections": "100",
+		},
+	}, nil)
+
+	server, err := factories["ccx"]()
+	require.NoError(t, err)
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	require.Empty(t, schemas.Diagnostics)
+
+	providerType := schemas.Provider.ValueType()
+	resourceType := schemas.ResourceSchemas["ccx_parameter_group"].ValueType()
+
+	providerConfig, err := tfprotov5.NewDynamicValue(providerType, tftypes.NewValue(providerType, map[string]tftypes.Value{
+		"client_id":     tftypes.NewValue(tftypes.String, nil),
+		"client_secret": tftypes.NewValue(tftypes.String, nil),
+		"base_url":      tftypes.NewValue(tftypes.String, nil),
+		"timeout":       tftypes.NewValue(tftypes.String, nil),
+	}))
+	require.NoError(t, err)
+
+	configured, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: &providerConfig})
+	require.NoError(t, err)
+	require.Empty(t, configured.Diagnostics)
+
+	// the state written by the SDK is upgraded without changes
+	upgraded, err := server.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: "ccx_parameter_group",
+		Version:  0,
+		RawState: &tfprotov5.RawState{JSON: []byte(fmt.Sprintf(sdkParameterGroupState, stored.DatabaseType, stored.DatabaseVendor, stored.DatabaseVersion))},
+	})
+	require.NoError(t, err)
+	require.Empty(t, upgraded.Diagnostics)
+
+	prior, err := upgraded.UpgradedState.Unmarshal(resourceType)
+	require.NoError(t, err)
+
+	// refreshing does not change the state
+	read, err := server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName:     "ccx_parameter_group",
+		CurrentState: upgraded.UpgradedState,
+		Private:      []byte("null"), // as written by the SDK
+	})
+	require.NoError(t, err)
+	require.Empty(t, read.Diagnostics)
+
+	refreshed, err := read.NewState.Unmarshal(resourceType)
+	require.NoError(t, err)
+	require.True(t, prior.Equal(refreshed), "state changed on refresh: %s", refreshed)
+
+	// planning the configuration which was applied with the SDK version does not change anything
+	configValue := func(id, description any) tftypes.Value {
+		return tftypes.NewValue(resourceType, map[string]tftypes.Value{
+			"id":               tftypes.NewValue(tftypes.String, id),
+			"name":             tftypes.NewValue(tftypes.String, "asteroid"),
+			"database_vendor":  tftypes.NewValue(tftypes.String, applied.DatabaseVendor),
+			"database_version": tftypes.NewValue(tftypes.String, applied.DatabaseVersion),
+			"database_type":    tftypes.NewValue(tftypes.String, applied.DatabaseType),
+			"description":      tftypes.NewValue(tftypes.String, description),
+			"parameters": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"max_connections": tftypes.NewValue(tftypes.String, "100"),
+			}),
+		})
+	}
+
+	config, err := tfprotov5.NewDynamicValue(resourceType, configValue(nil, nil))
+	require.NoError(t, err)
+
+	// as proposed by Terraform, the configured values with the computed values of the prior state
+	proposed, err := tfprotov5.NewDynamicValue(resourceType, configValue("parameter-group-id", ""))
+	require.NoError(t, err)
+
+	planned, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         "ccx_parameter_group",
+		PriorState:       read.NewState,
+		ProposedNewState: &proposed,
+		Config:           &config,
+		PriorPrivate:     read.Private,
+	})
+	require.NoError(t, err)
+	require.Empty(t, planned.Diagnostics)
+	require.Empty(t, planned.RequiresReplace)
+
+	plan, err := planned.PlannedState.Unmarshal(resourceType)
+	require.NoError(t, err)
+	require.True(t, prior.Equal(plan), "plan changes the state: %s", plan)
+}
+
+func TestParameterGroup_VendorAlias(t *testing.T) {
+	m, factories := mockProtoV5Provider(t)
+
+	created := ccx.ParameterGroup{
+		ID:              "parameter-group-id",
+		Name:            "asteroid",
+		DatabaseVendor:  "percona",
+		DatabaseVersion: "8",
+		DatabaseType:    "replication",
+		DbParameters: map[string]string{
+			"max_connections": "100",
+		},
+	}
+
+	m.content.EXPECT().DBVendors(mock.Anything).Return([]ccx.DBVendorInfo{
+		{
+			Name:           "percona",
+			Code:           "percona",
+			DefaultVersion: "8",
+			Versions:       []string{"8"},
+			Types:          []ccx.DBVendorInfoType{{Name: "replication", Code: "replication"}},
+			NumNodes:       []int{1, 2, 3},
+		},
+	}, nil)
+
+	m.parameterGroup.EXPECT().Create(mock.Anything, ccx.ParameterGroup{
+		Name:            "asteroid",
+		DatabaseVendor:  "percona",
+		DatabaseVersion: "8",
+		DatabaseType:    "replication",
+		DbParameters: map[string]string{
+			"max_connections": "100",
+		},
+	}).Return(&created, nil)
+	m.parameterGroup.EXPECT().Read(mock.Anything, "parameter-group-id").Return(&created, nil)
+	m.parameterGroup.EXPECT().Delete(mock.Anything, "parameter-group-id").Return(nil)
+
+	config := `
+resource "ccx_parameter_group" "asteroid" {
+  name             = "asteroid"
+  database_vendor  = "%s"
+  database_version = "8"
+  database_type    = "replication"
+
+  parameters = {
+    max_connections = 100
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV5ProviderFactories: factories,
+		Steps: []resource.TestStep{
+			{
+				// the configured spelling is kept, so there is no diff after refreshing
+				Config: fmt.Sprintf(config, "mysql"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ccx_parameter_group.asteroid", "database_vendor", "mysql"),
+					resource.TestCheckResourceAttr("ccx_parameter_group.asteroid", "description", ""),
+				),
+			},
+			{
+				// the name used by CCX is equivalent, so the spelling in state is kept
+				Config:   fmt.Sprintf(config, "percona"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
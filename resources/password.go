@@ -0,0 +1,47 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+)
+
+// credentialAttributes are computed from the password, so they change when the password is rotated
+var credentialAttributes = []string{"password", "primary_dsn", "replica_dsn", "connection_strings"}
+
+// rotatePasswordDiff marks the credentials as known after apply, when the password is going to be rotated
+// a new datastore gets a new password anyway, so nothing is rotated when creating one
+func rotatePasswordDiff(d *schema.ResourceDiff) error {
+	if d.Id() == "" || !d.HasChange("password_rotation_trigger") {
+		return nil
+	}
+
+	for _, k := range credentialAttributes {
+		if err := d.SetNewComputed(k); err != nil {
+			return fmt.Errorf("setting %s as computed: %w", k, err)
+		}
+	}
+
+	return nil
+}
+
+// rotatePassword rotates the password of the default user, and re-reads the datastore for the new password and DSNs
+func (r *Datastore) rotatePassword(ctx context.Context, id string) (*ccx.Datastore, error) {
+	tflog.Info(ctx, "rotating password", map[string]any{
+		"id": id,
+	})
+
+	if err := r.svc.RotatePassword(ctx, id); err != nil {
+		return nil, err
+	}
+
+	n, err := r.svc.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("reading datastore after rotating password: %w", err)
+	}
+
+	return n, nil
+}
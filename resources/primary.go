@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+)
+
+// configuredString returns the value of the attribute from the configuration, or an empty string when not configured
+// the preferred primary attributes are also computed, so the state may hold the current primary instead of the preference
+func configuredString(d *schema.ResourceData, key string) string {
+	c := d.GetRawConfig()
+	if !c.IsKnown() || c.IsNull() || !c.Type().IsObjectType() || !c.Type().HasAttribute(key) {
+		return ""
+	}
+
+	v := c.GetAttr(key)
+	if !v.IsKnown() || v.IsNull() {
+		return ""
+	}
+
+	return v.AsString()
+}
+
+// preferredPrimary returns the id of the host to promote, so that the primary is hostID or in the az
+// an empty id is returned when the current primary already matches the preference
+func preferredPrimary(hosts []ccx.Host, az, hostID string) (string, error) {
+	if az == "" && hostID == "" {
+		return "", nil
+	}
+
+	i := slices.IndexFunc(hosts, func(h ccx.Host) bool {
+		return h.IsPrimary()
+	})
+
+	if i == -1 {
+		return "", fmt.Errorf("%w: datastore has no primary", ccx.ErrPromoteNotAllowed)
+	}
+
+	primary := hosts[i]
+
+	if hostID != "" {
+		if primary.ID == hostID {
+			return "", nil
+		}
+
+		return hostID, ccx.CanPromote(hosts, hostID)
+	}
+
+	if primary.AZ == az {
+		return "", nil
+	}
+
+	candidates := slices.DeleteFunc(slices.Clone(hosts), func(h ccx.Host) bool {
+		return h.AZ != az || ccx.CanPromote(hosts, h.ID) != nil
+	})
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("%w: no healthy replica in availability zone %q", ccx.ErrPromoteNotAllowed, az)
+	}
+
+	slices.SortStableFunc(candidates, func(a, b ccx.Host) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return candidates[0].ID, nil
+}
+
+// switchPrimary promotes a replica when the primary does not match preferred_primary_host or preferred_primary_az
+// the datastore is read again after promoting, so that the hosts reflect the new primary
+func (r *Datastore) switchPrimary(ctx context.Context, d *schema.ResourceData, c ccx.Datastore) (*ccx.Datastore, error) {
+	hostID, err := preferredPrimary(c.Hosts, configuredString(d, "preferred_primary_az"), configuredString(d, "preferred_primary_host"))
+	if err != nil {
+		return nil, err
+	} else if hostID == "" {
+		return &c, nil
+	}
+
+	tflog.Info(ctx, "promoting replica to primary", map[string]any{
+		"id": c.ID, "host": hostID,
+	})
+
+	if err := r.svc.PromoteReplica(ctx, c.ID, hostID); err != nil {
+		return nil, err
+	}
+
+	n, err := r.svc.Read(ctx, c.ID)
+	if err != nil {
+		return nil, fmt.Errorf("reading datastore after promoting replica: %w", err)
+	}
+
+	return n, nil
+}
+
+// setPrimary sets the preferred primary attributes to the current primary
+func setPrimary(d *schema.ResourceData, hosts []ccx.Host) error {
+	var primary ccx.Host
+
+	if i := slices.IndexFunc(hosts, func(h ccx.Host) bool { return h.IsPrimary() }); i != -1 {
+		primary = hosts[i]
+	}
+
+	if err := d.Set("preferred_primary_az", primary.AZ); err != nil {
+		return err
+	}
+
+	return d.Set("preferred_primary_host", primary.ID)
+}
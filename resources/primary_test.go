@@ -0,0 +1,50 @@
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_preferredPrimary(t *testing.T) {
+	hosts := []ccx.Host{
+		{ID: "host-1", CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), AZ: "eu-north-1a", Role: "primary", Status: "ok"},
+		{ID: "host-3", CreatedAt: time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC), AZ: "eu-north-1b", Role: "replica", Status: "ok"},
+		{ID: "host-2", CreatedAt: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), AZ: "eu-north-1b", Role: "replica", Status: "ok"},
+		{ID: "host-4", CreatedAt: time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC), AZ: "eu-north-1c", Role: "replica", Status: "failed"},
+	}
+
+	tests := []struct {
+		name    string
+		az      string
+		hostID  string
+		want    string
+		wantErr bool
+	}{
+		{name: "no preference"},
+		{name: "primary already in az", az: "eu-north-1a"},
+		{name: "primary already the host", hostID: "host-1"},
+		{name: "oldest healthy replica in az", az: "eu-north-1b", want: "host-2"},
+		{name: "host", hostID: "host-3", want: "host-3"},
+		{name: "no healthy replica in az", az: "eu-north-1c", wantErr: true},
+		{name: "unknown az", az: "eu-north-1d", wantErr: true},
+		{name: "unhealthy host", hostID: "host-4", wantErr: true},
+		{name: "unknown host", hostID: "host-5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := preferredPrimary(hosts, tt.az, tt.hostID)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ccx.ErrPromoteNotAllowed)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -10,6 +10,17 @@ import (
 	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
 )
 
+// provider settings, shared by the SDK and the framework providers, as their schemas must be the same
+const (
+	defaultBaseURL = "https://app.mydbservice.net"
+	defaultTimeout = "60m"
+
+	clientIDDescription     = "OAuth client ID, which can be created in the CCX UI."
+	clientSecretDescription = "OAuth client secret, which can be created in the CCX UI."
+	baseURLDescription      = "If you are using a CCX instance other than the public service provided by Severalnines, set this value. It should be as a URL, e.g. `https://ccx.mycloud.com`."
+	timeoutDescription      = "Optionally, set a timeout for something. The default is `60m` meaning 60 minutes."
+)
+
 type providerConfig struct {
 	ClientID     string
 	ClientSecret string
@@ -17,11 +28,46 @@ type providerConfig struct {
 	Timeout      time.Duration
 }
 
+// services are the CCX clients, shared by the resources of a provider
+type services struct {
+	datastore      ccx.DatastoresService
+	vpc            ccx.VPCsService
+	vpcPeering     ccx.VPCPeeringsService
+	parameterGroup ccx.ParameterGroupsService
+	content        ccx.ContentService
+}
+
+func newServices(cfg providerConfig) (*services, error) {
+	httpClient := ccx.NewHTTPClient(cfg.BaseURL, cfg.ClientID, cfg.ClientSecret)
+
+	contentSvc, err := ccx.NewContentClient(httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	datastoreSvc, err := ccx.NewDatastoresClient(httpClient, cfg.Timeout, contentSvc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services{
+		datastore:      datastoreSvc,
+		vpc:            ccx.NewVPCsClient(httpClient),
+		vpcPeering:     ccx.NewVPCPeeringsClient(httpClient),
+		parameterGroup: ccx.NewParameterGroupsClient(httpClient),
+		content:        contentSvc,
+	}, nil
+}
+
 func Provider() *schema.Provider {
 	// make resource managers, so they are ready to be used in schema, but we can't set services into them until configure is called
 	datastore := &Datastore{}
 	vpc := &VPC{}
-	parameterGroup := &ParameterGroup{}
+	vpcPeering := &VPCPeering{}
+	firewallRule := &FirewallRule{}
+	ipSet := &IPSet{}
+	vpcData := &VPCDataSource{}
+	vpcsData := &VPCsDataSource{}
 
 	configure := func(ctx context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
 		cfg := providerConfig{
@@ -36,71 +82,70 @@ func Provider() *schema.Provider {
 			return nil, diag.Errorf("invalid timeout (%s): %s", getString(d, "timeout"), err)
 		}
 
-		httpClient := ccx.NewHTTPClient(cfg.BaseURL, cfg.ClientID, cfg.ClientSecret)
-
-		contentSvc, err := ccx.NewContentClient(httpClient)
+		svc, err := newServices(cfg)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
 
-		datastoreSvc, err := ccx.NewDatastoresClient(httpClient, cfg.Timeout, contentSvc)
-		if err != nil {
-			return nil, diag.FromErr(err)
-		}
-
-		parameterGroupSvc := ccx.NewParameterGroupsClient(httpClient)
-
-		vpcSvc := ccx.NewVPCsClient(httpClient)
-
 		// set services into resources, now that it is possible
 
-		datastore.svc = datastoreSvc
-		datastore.contentSvc = contentSvc
-		datastore.pgSvc = parameterGroupSvc
+		datastore.svc = svc.datastore
+		datastore.contentSvc = svc.content
+		datastore.pgSvc = svc.parameterGroup
 
-		parameterGroup.svc = parameterGroupSvc
-		parameterGroup.contentSvc = contentSvc
+		vpc.svc = svc.vpc
+		vpc.datastoreSvc = svc.datastore
+		vpcPeering.svc = svc.vpcPeering
+		vpcData.svc = svc.vpc
+		vpcsData.svc = svc.vpc
 
-		vpc.svc = vpcSvc
+		firewallRule.svc = svc.datastore
+		ipSet.svc = svc.datastore
 
 		return nil, nil
 	}
 
-	return makeProvider(configure, datastore, vpc, parameterGroup)
+	return makeProvider(configure, datastore, vpc, vpcPeering, firewallRule, ipSet, vpcData, vpcsData)
 }
 
-func makeProvider(configure schema.ConfigureContextFunc, datastore *Datastore, vpc *VPC, parameterGroup *ParameterGroup) *schema.Provider {
+func makeProvider(configure schema.ConfigureContextFunc, datastore *Datastore, vpc *VPC, vpcPeering *VPCPeering, firewallRule *FirewallRule, ipSet *IPSet, vpcData *VPCDataSource, vpcsData *VPCsDataSource) *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"client_id": {
 				Type:        schema.TypeString,
 				Required:    true,
 				DefaultFunc: schema.EnvDefaultFunc("CCX_CLIENT_ID", ""),
-				Description: "OAuth client ID, which can be created in the CCX UI.",
+				Description: clientIDDescription,
 			},
 			"client_secret": {
 				Type:        schema.TypeString,
 				Required:    true,
 				DefaultFunc: schema.EnvDefaultFunc("CCX_CLIENT_SECRET", ""),
-				Description: "OAuth client secret, which can be created in the CCX UI.",
+				Description: clientSecretDescription,
 			},
 			"base_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("CCX_BASE_URL", "https://app.mydbservice.net"),
-				Description: "If you are using a CCX instance other than the public service provided by Severalnines, set this value. It should be as a URL, e.g. `https://ccx.mycloud.com`.",
+				DefaultFunc: schema.EnvDefaultFunc("CCX_BASE_URL", defaultBaseURL),
+				Description: baseURLDescription,
 			},
 			"timeout": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("CCX_TIMEOUT", "60m"),
-				Description: "Optionally, set a timeout for something. The default is `60m` meaning 60 minutes.",
+				DefaultFunc: schema.EnvDefaultFunc("CCX_TIMEOUT", defaultTimeout),
+				Description: timeoutDescription,
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"ccx_datastore":       datastore.Schema(),
-			"ccx_vpc":             vpc.Schema(),
-			"ccx_parameter_group": parameterGroup.Schema(),
+			"ccx_datastore":     datastore.Schema(),
+			"ccx_vpc":           vpc.Schema(),
+			"ccx_vpc_peering":   vpcPeering.Schema(),
+			"ccx_firewall_rule": firewallRule.Schema(),
+			"ccx_ip_set":        ipSet.Schema(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"ccx_vpc":  vpcData.Schema(),
+			"ccx_vpcs": vpcsData.Schema(),
 		},
 		ConfigureContextFunc: configure,
 	}
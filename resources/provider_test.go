@@ -3,16 +3,22 @@ package resources
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type mockServices struct {
 	datastore      *ccx.MockDatastoresService
 	vpc            *ccx.MockVPCsService
+	vpcPeering     *ccx.MockVPCPeeringsService
 	parameterGroup *ccx.MockParameterGroupsService
 	content        *ccx.MockContentService
 }
@@ -20,31 +26,96 @@ type mockServices struct {
 func (m mockServices) AssertExpectations(t mock.TestingT) {
 	m.datastore.AssertExpectations(t)
 	m.vpc.AssertExpectations(t)
+	m.vpcPeering.AssertExpectations(t)
 	m.parameterGroup.AssertExpectations(t)
 }
 
 func mockProvider(t *testing.T) (mockServices, *schema.Provider) {
 	datastore := &Datastore{}
-	vpc := &VPC{}
-	parameterGroup := &ParameterGroup{}
+	vpc := &VPC{tick: 10 * time.Millisecond}
+	vpcPeering := &VPCPeering{}
+	firewallRule := &FirewallRule{}
+	ipSet := &IPSet{}
+	vpcData := &VPCDataSource{}
+	vpcsData := &VPCsDataSource{}
 
 	services := mockServices{
 		datastore:      ccx.NewMockDatastoresService(t),
 		vpc:            ccx.NewMockVPCsService(t),
+		vpcPeering:     ccx.NewMockVPCPeeringsService(t),
 		parameterGroup: ccx.NewMockParameterGroupsService(t),
 		content:        ccx.NewMockContentService(t),
 	}
 
 	configure := func(ctx context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
 		vpc.svc = services.vpc
-		parameterGroup.svc = services.parameterGroup
-		parameterGroup.contentSvc = services.content
+		vpc.datastoreSvc = services.datastore
+		vpcPeering.svc = services.vpcPeering
+		vpcData.svc = services.vpc
+		vpcsData.svc = services.vpc
 		datastore.svc = services.datastore
 		datastore.contentSvc = services.content
 		datastore.pgSvc = services.parameterGroup
+		firewallRule.svc = services.datastore
+		ipSet.svc = services.datastore
 
 		return nil, nil
 	}
 
-	return services, makeProvider(configure, datastore, vpc, parameterGroup)
+	return services, makeProvider(configure, datastore, vpc, vpcPeering, firewallRule, ipSet, vpcData, vpcsData)
+}
+
+// mockProtoV5Provider returns the SDK and the framework providers muxed, like in main, with mocked services
+func mockProtoV5Provider(t *testing.T) (mockServices, map[string]func() (tfprotov5.ProviderServer, error)) {
+	services, sdkProvider := mockProvider(t)
+
+	parameterGroup := &ParameterGroup{}
+	credentials := &DatastoreCredentials{}
+
+	configure := func(ctx context.Context, cfg providerConfig) error {
+		parameterGroup.svc = services.parameterGroup
+		parameterGroup.contentSvc = services.content
+		credentials.svc = services.datastore
+
+		return nil
+	}
+
+	frameworkProvider := makeFrameworkProvider(configure, parameterGroup, credentials)
+
+	factories := map[string]func() (tfprotov5.ProviderServer, error){
+		"ccx": func() (tfprotov5.ProviderServer, error) {
+			muxServer, err := tf5muxserver.NewMuxServer(context.Background(),
+				sdkProvider.GRPCProvider,
+				providerserver.NewProtocol5(frameworkProvider),
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return muxServer.ProviderServer(), nil
+		},
+	}
+
+	return services, factories
+}
+
+func TestProvider_Mux(t *testing.T) {
+	ctx := context.Background()
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, Provider().GRPCProvider, providerserver.NewProtocol5(FrameworkProvider()))
+	require.NoError(t, err)
+
+	// muxing fails when the provider schemas of the SDK and the framework providers differ
+	rs, err := muxServer.ProviderServer().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	require.Empty(t, rs.Diagnostics)
+
+	require.Contains(t, rs.ResourceSchemas, "ccx_datastore")
+	require.Contains(t, rs.ResourceSchemas, "ccx_parameter_group")
+	require.Contains(t, rs.ResourceSchemas, "ccx_firewall_rule")
+	require.Contains(t, rs.ResourceSchemas, "ccx_ip_set")
+	require.Contains(t, rs.ResourceSchemas, "ccx_vpc_peering")
+	require.Contains(t, rs.DataSourceSchemas, "ccx_vpc")
+	require.Contains(t, rs.DataSourceSchemas, "ccx_vpcs")
+	require.Contains(t, rs.EphemeralResourceSchemas, "ccx_datastore_credentials")
 }
@@ -1,7 +1,11 @@
 package resources
 
 import (
+	"slices"
+
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
 )
 
 func getString(d *schema.ResourceData, key string) string {
@@ -155,7 +159,15 @@ func allKeysSet(d *schema.ResourceData, keys ...string) bool {
 	return true
 }
 
+// getAzs returns the configured availability zones
+// network_az is also computed, to be able to fill it on import, so when the config is available it decides whether azs are set
 func getAzs(d *schema.ResourceData) ([]string, bool) {
+	if c := d.GetRawConfig(); hasAttribute(c, "network_az") {
+		if v := c.GetAttr("network_az"); v.IsNull() {
+			return nil, false
+		}
+	}
+
 	if _, ok := d.GetOk("network_az"); ok {
 		azs := getStrings(d, "network_az")
 		return azs, true
@@ -163,3 +175,49 @@ func getAzs(d *schema.ResourceData) ([]string, bool) {
 
 	return nil, false
 }
+
+// networkAzs returns the availability zones to store for the hosts: the configured ones when set, else the zones of the hosts
+// without config, i.e. on refresh and import, the zones in the state are kept only while they are the zones of the hosts, so that drift is detected
+func networkAzs(d *schema.ResourceData, hosts []ccx.Host) []string {
+	actual := hostAzs(hosts)
+
+	if !hasAttribute(d.GetRawConfig(), "network_az") {
+		if azs := getStrings(d, "network_az"); sameElements(azs, actual) {
+			return azs
+		}
+
+		return actual
+	}
+
+	if azs, ok := getAzs(d); ok {
+		return azs
+	}
+
+	return actual
+}
+
+// hasAttribute reports whether the raw config is available and has the attribute
+// the config is null outside of plan and apply
+func hasAttribute(c cty.Value, key string) bool {
+	return c.IsKnown() && !c.IsNull() && c.Type().IsObjectType() && c.Type().HasAttribute(key)
+}
+
+// isConfigured reports whether the attribute is set in the raw config
+func isConfigured(c cty.Value, key string) bool {
+	return hasAttribute(c, key) && !c.GetAttr(key).IsNull()
+}
+
+// isUnknown reports whether the attribute is unknown, e.g. in the plan of a computed attribute which is recomputed
+func isUnknown(c cty.Value, key string) bool {
+	return hasAttribute(c, key) && !c.GetAttr(key).IsKnown()
+}
+
+// sameElements reports whether a and b have the same elements, in any order
+func sameElements(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(a, b)
+}
@@ -3,7 +3,14 @@ package resources
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
@@ -11,8 +18,30 @@ import (
 
 const vpcDoc = `A VPC`
 
+// prefix lengths allowed for the CIDR of a VPC, which is split into subnets for the availability zones
+const (
+	vpcMinPrefixLength = 16
+	vpcMaxPrefixLength = 24
+)
+
+// default timeouts of a vpc, and the time to wait between checks for attached datastores when deleting
+const (
+	vpcCreateTimeout = 20 * time.Minute
+	vpcDeleteTimeout = 20 * time.Minute
+	vpcDeleteTick    = 15 * time.Second
+)
+
+// values of cidr_overlap_check
+const (
+	overlapCheckOff   = "off"
+	overlapCheckWarn  = "warn"
+	overlapCheckError = "error"
+)
+
 type VPC struct {
-	svc ccx.VPCsService
+	svc          ccx.VPCsService
+	datastoreSvc ccx.DatastoresService
+	tick         time.Duration // overrides vpcDeleteTick, e.g. in tests
 }
 
 func (r *VPC) Schema() *schema.Resource {
@@ -20,42 +49,89 @@ func (r *VPC) Schema() *schema.Resource {
 		Description: vpcDoc,
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the VPC.",
+			},
+			"cloud_space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Cloud space of the VPC, when the cloud provider has more than one.",
 			},
 			"cloud_provider": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Cloud provider of the VPC, e.g. aws.",
 			},
 			"cloud_region": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Cloud region of the VPC, e.g. eu-north-1.",
 			},
 			"ipv4_cidr": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				Description:      fmt.Sprintf("IPv4 CIDR of the VPC, e.g. 10.10.0.0/16. The prefix length must be between /%d and /%d.", vpcMinPrefixLength, vpcMaxPrefixLength),
+				ValidateDiagFunc: validateVPCCIDR,
+				DiffSuppressFunc: cidrSuppressor,
+			},
+			"cidr_overlap_check": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          overlapCheckOff,
+				Description:      "Checks whether `ipv4_cidr` overlaps with the CIDRs of the other VPCs, which would prevent peering them. With `error` the plan fails. With `warn` the VPCs are only checked when the VPC is created, so the warning is reported after the apply has started, and the VPC is created anyway. With `off` the VPCs are not checked.",
+				ValidateDiagFunc: validateOverlapCheck,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(vpcCreateTimeout),
+			Delete: schema.DefaultTimeout(vpcDeleteTimeout),
+		},
+		CustomizeDiff: r.CustomizeDiff,
 		CreateContext: r.Create,
 		ReadContext:   r.Read,
 		UpdateContext: r.Update,
 		DeleteContext: r.Delete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: r.Import,
 		},
 	}
 }
 
 func (r *VPC) Create(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
 	v := vpcFromSchema(d)
+
+	var diags diag.Diagnostics
+
+	if getString(d, "cidr_overlap_check") == overlapCheckWarn && v.CidrIpv4Block != "" {
+		if err := r.checkOverlap(ctx, v.ID, v.CidrIpv4Block); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       "VPC CIDR overlaps",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("ipv4_cidr"),
+			})
+		}
+	}
+
 	n, err := r.svc.Create(ctx, v)
-	if err != nil {
+	if err != nil && n != nil && n.ID != "" {
+		// the vpc was created but is not ready, so it is stored to be replaced by the next apply
+		d.SetId(n.ID)
+		return append(diags, diag.FromErr(err)...)
+	} else if err != nil {
 		d.SetId("")
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
-	return diag.FromErr(fillSchemaFromVPC(*n, d))
+	return append(diags, diag.FromErr(fillSchemaFromVPC(*n, d))...)
 }
 
 func (r *VPC) Read(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
@@ -72,6 +148,11 @@ func (r *VPC) Read(ctx context.Context, d *schema.ResourceData, _ any) diag.Diag
 }
 
 func (r *VPC) Update(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	// only the name is updated in place, other changes e.g. to cidr_overlap_check are only stored
+	if !d.HasChange("name") {
+		return nil
+	}
+
 	v := vpcFromSchema(d)
 	n, err := r.svc.Update(ctx, v)
 	if err != nil {
@@ -81,13 +162,114 @@ func (r *VPC) Update(ctx context.Context, d *schema.ResourceData, _ any) diag.Di
 	return diag.FromErr(fillSchemaFromVPC(*n, d))
 }
 
+// Delete waits for the datastores attached to the vpc to be deleted, e.g. by the same apply, until the delete timeout is reached
 func (r *VPC) Delete(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
 	v := vpcFromSchema(d)
+
+	tick := r.tick
+	if tick == 0 {
+		tick = vpcDeleteTick
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		attached, err := r.attachedDatastores(ctx, v.ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if len(attached) == 0 {
+			break
+		}
+
+		tflog.Info(ctx, "waiting for datastores to be detached from vpc", map[string]any{"id": v.ID, "datastores": attached})
+
+		select {
+		case <-ctx.Done():
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "VPC has datastores attached",
+				Detail: fmt.Sprintf("VPC %s (%s) cannot be deleted, as datastores are still attached to it: %s. "+
+					"Delete the datastores first, or increase the delete timeout if they are being deleted.", v.Name, v.ID, strings.Join(attached, ", ")),
+			}}
+		case <-ticker.C:
+		}
+	}
+
 	return diag.FromErr(r.svc.Delete(ctx, v.ID))
 }
 
+// attachedDatastores returns the names and IDs of the datastores in the vpc, including those still being deleted
+func (r *VPC) attachedDatastores(ctx context.Context, id string) ([]string, error) {
+	ls, err := r.datastoreSvc.ListInVPC(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("listing datastores attached to vpc: %w", err)
+	}
+
+	attached := make([]string, 0, len(ls))
+
+	for _, c := range ls {
+		attached = append(attached, fmt.Sprintf("%s (%s)", c.Name, c.ID))
+	}
+
+	return attached, nil
+}
+
+// Import a vpc by ID, cidr_overlap_check is set to its default, as defaults are not set on import
+func (r *VPC) Import(_ context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+	if err := d.Set("cidr_overlap_check", overlapCheckOff); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// CustomizeDiff fails the plan when the CIDR overlaps with other VPCs and cidr_overlap_check is error
+func (r *VPC) CustomizeDiff(ctx context.Context, d *schema.ResourceDiff, _ any) error {
+	if !d.HasChange("ipv4_cidr") || !d.NewValueKnown("ipv4_cidr") {
+		return nil
+	}
+
+	mode, _ := d.Get("cidr_overlap_check").(string)
+	cidr, _ := d.Get("ipv4_cidr").(string)
+
+	if mode != overlapCheckError || cidr == "" {
+		return nil
+	}
+
+	return r.checkOverlap(ctx, d.Id(), cidr)
+}
+
+// checkOverlap returns an error listing the other VPCs whose CIDR overlaps with cidr
+func (r *VPC) checkOverlap(ctx context.Context, id, cidr string) error {
+	ls, err := r.svc.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing vpcs to check the CIDR for overlaps: %w", err)
+	}
+
+	var overlapping []string
+
+	for _, v := range ls {
+		if v.ID == id || v.CidrIpv4Block == "" {
+			continue
+		}
+
+		if ok, err := ccx.CIDRsOverlap(cidr, v.CidrIpv4Block); err == nil && ok {
+			overlapping = append(overlapping, fmt.Sprintf("%s (%s, %s)", v.Name, v.ID, v.CidrIpv4Block))
+		}
+	}
+
+	if len(overlapping) != 0 {
+		return fmt.Errorf("ipv4_cidr %s overlaps with the CIDRs of the VPCs: %s", cidr, strings.Join(overlapping, ", "))
+	}
+
+	return nil
+}
+
 func vpcFromSchema(d *schema.ResourceData) ccx.VPC {
-	return ccx.VPC{
+	v := ccx.VPC{
 		ID:            d.Id(),
 		Name:          getString(d, "name"),
 		CloudSpace:    getString(d, "cloud_space"),
@@ -95,6 +277,60 @@ func vpcFromSchema(d *schema.ResourceData) ccx.VPC {
 		Region:        getString(d, "cloud_region"),
 		CidrIpv4Block: getString(d, "ipv4_cidr"),
 	}
+
+	// the cidr is validated at plan time
+	if c, err := ccx.CanonicalCIDR(v.CidrIpv4Block); err == nil {
+		v.CidrIpv4Block = c
+	}
+
+	return v
+}
+
+// validateVPCCIDR validates the CIDR of a VPC at plan time, it must be IPv4 and leave room for the subnets of the datastores
+func validateVPCCIDR(v any, p cty.Path) diag.Diagnostics {
+	if diags := validateCIDR(v, p); diags.HasError() {
+		return diags
+	}
+
+	s, _ := v.(string)
+
+	c, _ := ccx.CanonicalCIDR(s)
+	prefix := netip.MustParsePrefix(c)
+
+	if !prefix.Addr().Is4() {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "invalid VPC CIDR",
+			Detail:        fmt.Sprintf("%q is not an IPv4 CIDR, e.g. 10.10.0.0/16", s),
+			AttributePath: p,
+		}}
+	}
+
+	if prefix.Bits() < vpcMinPrefixLength || prefix.Bits() > vpcMaxPrefixLength {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "invalid VPC CIDR",
+			Detail:        fmt.Sprintf("the prefix length of %q must be between /%d and /%d", s, vpcMinPrefixLength, vpcMaxPrefixLength),
+			AttributePath: p,
+		}}
+	}
+
+	return nil
+}
+
+func validateOverlapCheck(v any, p cty.Path) diag.Diagnostics {
+	s, _ := v.(string)
+
+	if !slices.Contains([]string{overlapCheckOff, overlapCheckWarn, overlapCheckError}, s) {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "invalid cidr_overlap_check",
+			Detail:        fmt.Sprintf("%q is not one of %s, %s or %s", s, overlapCheckOff, overlapCheckWarn, overlapCheckError),
+			AttributePath: p,
+		}}
+	}
+
+	return nil
 }
 
 func fillSchemaFromVPC(v ccx.VPC, d *schema.ResourceData) error {
@@ -0,0 +1,206 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+)
+
+const vpcDataSourceDoc = `Looks up a VPC by name or ID, e.g. to set ` + "`network_vpc_uuid`" + ` of a datastore from a well-known VPC name.`
+
+const vpcsDataSourceDoc = `Lists the VPCs, optionally filtered by cloud provider and region.`
+
+// vpcDataSourceSchema returns the attributes of a VPC, as read by the data sources
+func vpcDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the VPC.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the VPC.",
+		},
+		"cloud_space": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Cloud space of the VPC.",
+		},
+		"cloud_provider": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Cloud provider of the VPC.",
+		},
+		"cloud_region": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Cloud region of the VPC.",
+		},
+		"ipv4_cidr": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "IPv4 CIDR of the VPC.",
+		},
+	}
+}
+
+type VPCDataSource struct {
+	svc ccx.VPCsService
+}
+
+func (r *VPCDataSource) Schema() *schema.Resource {
+	s := vpcDataSourceSchema()
+
+	s["id"].Optional = true
+	s["id"].ExactlyOneOf = []string{"id", "name"}
+	s["id"].Description = "ID of the VPC. Either `id` or `name` must be set."
+
+	s["name"].Optional = true
+	s["name"].ExactlyOneOf = []string{"id", "name"}
+	s["name"].Description = "Name of the VPC. Either `id` or `name` must be set, the name must be unique."
+
+	return &schema.Resource{
+		Description: vpcDataSourceDoc,
+		Schema:      s,
+		ReadContext: r.Read,
+	}
+}
+
+func (r *VPCDataSource) Read(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	var (
+		v   *ccx.VPC
+		err error
+	)
+
+	if id := getString(d, "id"); id != "" {
+		v, err = r.svc.Read(ctx, id)
+		if errors.Is(err, ccx.ErrResourceNotFound) {
+			return diag.Errorf("vpc %q not found", id)
+		}
+	} else {
+		v, err = r.findVPCByName(ctx, getString(d, "name"))
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(fillSchemaFromVPC(*v, d))
+}
+
+// findVPCByName returns the only VPC with the name
+func (r *VPCDataSource) findVPCByName(ctx context.Context, name string) (*ccx.VPC, error) {
+	ls, err := r.svc.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing vpcs: %w", err)
+	}
+
+	var found []ccx.VPC
+
+	for _, v := range ls {
+		if v.Name == name {
+			found = append(found, v)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("vpc named %q not found", name)
+	case 1:
+		return &found[0], nil
+	}
+
+	ids := make([]string, 0, len(found))
+	for _, v := range found {
+		ids = append(ids, v.ID)
+	}
+
+	return nil, fmt.Errorf("%d vpcs are named %q (%s), set id instead", len(found), name, strings.Join(ids, ", "))
+}
+
+type VPCsDataSource struct {
+	svc ccx.VPCsService
+}
+
+func (r *VPCsDataSource) Schema() *schema.Resource {
+	return &schema.Resource{
+		Description: vpcsDataSourceDoc,
+		Schema: map[string]*schema.Schema{
+			"cloud_provider": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the VPCs of the cloud provider, e.g. aws.",
+			},
+			"cloud_region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the VPCs in the cloud region, e.g. eu-north-1.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the VPCs.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"vpcs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The VPCs, sorted by name.",
+				Elem:        &schema.Resource{Schema: vpcDataSourceSchema()},
+			},
+		},
+		ReadContext: r.Read,
+	}
+}
+
+func (r *VPCsDataSource) Read(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	cloud := getString(d, "cloud_provider")
+	region := getString(d, "cloud_region")
+
+	ls, err := r.svc.List(ctx)
+	if err != nil {
+		return diag.Errorf("listing vpcs: %s", err)
+	}
+
+	var (
+		ids  = []any{}
+		vpcs = []any{}
+	)
+
+	slices.SortStableFunc(ls, func(a, b ccx.VPC) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, v := range ls {
+		if (cloud != "" && v.CloudProvider != cloud) || (region != "" && v.Region != region) {
+			continue
+		}
+
+		ids = append(ids, v.ID)
+		vpcs = append(vpcs, map[string]any{
+			"id":             v.ID,
+			"name":           v.Name,
+			"cloud_space":    v.CloudSpace,
+			"cloud_provider": v.CloudProvider,
+			"cloud_region":   v.Region,
+			"ipv4_cidr":      v.CidrIpv4Block,
+		})
+	}
+
+	// the id identifies the filters, as the data source has no id of its own
+	d.SetId("vpcs/" + cloud + "/" + region)
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("vpcs", vpcs))
+}
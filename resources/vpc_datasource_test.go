@@ -0,0 +1,109 @@
+package resources
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVPCDataSources(t *testing.T) {
+	m, p := mockProvider(t)
+
+	venus := ccx.VPC{ID: "vpc-1", Name: "venus", CloudSpace: "default", CloudProvider: "aws", Region: "eu-north-1", CidrIpv4Block: "10.10.0.0/16"}
+	mars := ccx.VPC{ID: "vpc-2", Name: "mars", CloudSpace: "default", CloudProvider: "aws", Region: "eu-west-1", CidrIpv4Block: "10.20.0.0/16"}
+	marsGCP := ccx.VPC{ID: "vpc-3", Name: "mars", CloudSpace: "default", CloudProvider: "gcp", Region: "europe-north1", CidrIpv4Block: "10.30.0.0/16"}
+
+	m.vpc.EXPECT().List(mock.Anything).Return([]ccx.VPC{venus, mars, marsGCP}, nil)
+	m.vpc.EXPECT().Read(mock.Anything, "vpc-2").Return(&mars, nil)
+	m.vpc.EXPECT().Read(mock.Anything, "vpc-9").Return(nil, ccx.ErrResourceNotFound)
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"ccx": func() (*schema.Provider, error) {
+				return p, nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "ccx_vpc" "pluto" {
+  id   = "vpc-9"
+  name = "pluto"
+}
+`,
+				ExpectError: regexp.MustCompile(`only one of`),
+			},
+			{
+				Config: `
+data "ccx_vpc" "venus" {
+  name = "venus"
+}
+
+data "ccx_vpc" "mars" {
+  id = "vpc-2"
+}
+
+data "ccx_vpcs" "aws" {
+  cloud_provider = "aws"
+}
+
+data "ccx_vpcs" "all" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ccx_vpc.venus", "id", "vpc-1"),
+					resource.TestCheckResourceAttr("data.ccx_vpc.venus", "cloud_region", "eu-north-1"),
+					resource.TestCheckResourceAttr("data.ccx_vpc.venus", "ipv4_cidr", "10.10.0.0/16"),
+					resource.TestCheckResourceAttr("data.ccx_vpc.mars", "name", "mars"),
+					resource.TestCheckResourceAttr("data.ccx_vpc.mars", "cloud_space", "default"),
+					resource.TestCheckResourceAttr("data.ccx_vpcs.aws", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.ccx_vpcs.aws", "ids.0", "vpc-2"),
+					resource.TestCheckResourceAttr("data.ccx_vpcs.aws", "ids.1", "vpc-1"),
+					resource.TestCheckResourceAttr("data.ccx_vpcs.aws", "vpcs.1.name", "venus"),
+					resource.TestCheckResourceAttr("data.ccx_vpcs.aws", "vpcs.1.ipv4_cidr", "10.10.0.0/16"),
+					resource.TestCheckResourceAttr("data.ccx_vpcs.all", "ids.#", "3"),
+				),
+			},
+			{
+				Config: `
+data "ccx_vpc" "mars" {
+  name = "mars"
+}
+`,
+				ExpectError: regexp.MustCompile(`2 vpcs are named "mars" \(vpc-2, vpc-3\), set id instead`),
+			},
+			{
+				Config: `
+data "ccx_vpc" "pluto" {
+  name = "pluto"
+}
+`,
+				ExpectError: regexp.MustCompile(`vpc named "pluto" not found`),
+			},
+			{
+				Config: `
+data "ccx_vpc" "pluto" {
+  id = "vpc-9"
+}
+`,
+				ExpectError: regexp.MustCompile(`vpc "vpc-9" not found`),
+			},
+			{
+				Config: `
+data "ccx_vpcs" "north" {
+  cloud_provider = "aws"
+  cloud_region   = "eu-north-1"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ccx_vpcs.north", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.ccx_vpcs.north", "vpcs.0.id", "vpc-1"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,245 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+)
+
+const vpcPeeringDoc = `
+A VPC peering connects a ` + "`ccx_vpc`" + ` to a VPC of your own cloud account, e.g. the VPC of the applications, so that they can reach the private datastores.
+
+CCX requests the peering, which must then be accepted on the peer side, e.g. with ` + "`aws_vpc_peering_connection_accepter`" + ` using ` + "`cloud_peering_id`" + `, or on GCP with a ` + "`google_compute_network_peering`" + ` to ` + "`cloud_vpc_id`" + ` in the project ` + "`cloud_account_id`" + `. Routes to the CIDR of the ` + "`ccx_vpc`" + ` must be added on the peer side too.
+
+Creating the peering waits until it is requested in the cloud, i.e. the ` + "`cloud_*`" + ` attributes are known. When the peering fails while it is created, e.g. it is rejected by the cloud, the apply fails with ` + "`status_message`" + ` and the peering is replaced by the next apply. A peering which fails later is only refreshed, with a failed ` + "`status`" + ` and the reason in ` + "`status_message`" + `, and is not replaced, e.g. use ` + "`terraform apply -replace`" + ` to request it again.`
+
+// default create timeout of a vpc peering, i.e. the time to wait until it is requested in the cloud
+const vpcPeeringCreateTimeout = 10 * time.Minute
+
+type VPCPeering struct {
+	svc ccx.VPCPeeringsService
+}
+
+func (r *VPCPeering) Schema() *schema.Resource {
+	return &schema.Resource{
+		Description: vpcPeeringDoc,
+		Schema: map[string]*schema.Schema{
+			"vpc_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the `ccx_vpc`.",
+			},
+			"peer_cloud_account_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Cloud account of the peer VPC, i.e. the AWS account ID or the GCP project ID.",
+			},
+			"peer_vpc_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the peer VPC, i.e. the AWS VPC ID or the GCP network name.",
+			},
+			"peer_cidr": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "CIDR of the peer VPC, which is routed to the peering. It must not overlap with the CIDR of the `ccx_vpc`.",
+				ValidateDiagFunc: validateCIDR,
+				DiffSuppressFunc: cidrSuppressor,
+			},
+			"peer_region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Region of the peer VPC. When not set, the region of the `ccx_vpc` is used.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the peering, e.g. `pending-acceptance` until it is accepted on the peer side, then `active`.",
+			},
+			"status_message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Details of the status, e.g. why the peering failed.",
+			},
+			"cloud_peering_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the peering in the cloud, e.g. `pcx-...` on AWS, which is accepted by `aws_vpc_peering_connection_accepter`.",
+			},
+			"cloud_account_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Cloud account of the `ccx_vpc`, i.e. the AWS account ID or the GCP project ID.",
+			},
+			"cloud_vpc_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the `ccx_vpc` in the cloud, i.e. the AWS VPC ID or the GCP network name.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(vpcPeeringCreateTimeout),
+		},
+		CreateContext: r.Create,
+		ReadContext:   r.Read,
+		DeleteContext: r.Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: r.Import,
+		},
+	}
+}
+
+func (r *VPCPeering) Create(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	p := vpcPeeringFromSchema(d)
+
+	n, err := r.svc.Create(ctx, p)
+	if err != nil && n != nil && n.ID != "" {
+		// the peering was created but is not requested, so it is stored with its status to be replaced by the next apply
+		if err := fillSchemaFromVPCPeering(*n, d); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return diag.FromErr(err)
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(fillSchemaFromVPCPeering(*n, d))
+}
+
+func (r *VPCPeering) Read(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	vpcID, id, err := parseVPCPeeringID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	n, err := r.svc.Read(ctx, vpcID, id)
+	if errors.Is(err, ccx.ErrResourceNotFound) {
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(fillSchemaFromVPCPeering(*n, d))
+}
+
+func (r *VPCPeering) Delete(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	vpcID, id, err := parseVPCPeeringID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := r.svc.Delete(ctx, vpcID, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// Import a vpc peering by <vpc_id>/<peering_id>
+func (r *VPCPeering) Import(ctx context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+	vpcID, id, err := parseVPCPeeringID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := r.svc.Read(ctx, vpcID, id)
+	if errors.Is(err, ccx.ErrResourceNotFound) {
+		return nil, fmt.Errorf("vpc peering %q not found", d.Id())
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := fillSchemaFromVPCPeering(*n, d); err != nil {
+		return nil, fmt.Errorf("setting schema: %w", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func vpcPeeringID(vpcID, id string) string {
+	return vpcID + "/" + id
+}
+
+func parseVPCPeeringID(s string) (vpcID, id string, err error) {
+	vpcID, id, ok := strings.Cut(s, "/")
+	if !ok || vpcID == "" || id == "" {
+		return "", "", fmt.Errorf("invalid vpc peering id %q, expected <vpc_id>/<peering_id>", s)
+	}
+
+	return vpcID, id, nil
+}
+
+func vpcPeeringFromSchema(d *schema.ResourceData) ccx.VPCPeering {
+	p := ccx.VPCPeering{
+		VpcID:              getString(d, "vpc_id"),
+		PeerCloudAccountID: getString(d, "peer_cloud_account_id"),
+		PeerVpcID:          getString(d, "peer_vpc_id"),
+		PeerCidrIpv4Block:  getString(d, "peer_cidr"),
+		PeerRegion:         getString(d, "peer_region"),
+	}
+
+	// the cidr is validated at plan time
+	if c, err := ccx.CanonicalCIDR(p.PeerCidrIpv4Block); err == nil {
+		p.PeerCidrIpv4Block = c
+	}
+
+	return p
+}
+
+func fillSchemaFromVPCPeering(p ccx.VPCPeering, d *schema.ResourceData) error {
+	d.SetId(vpcPeeringID(p.VpcID, p.ID))
+
+	if err := d.Set("vpc_id", p.VpcID); err != nil {
+		return err
+	}
+
+	if err := d.Set("peer_cloud_account_id", p.PeerCloudAccountID); err != nil {
+		return err
+	}
+
+	if err := d.Set("peer_vpc_id", p.PeerVpcID); err != nil {
+		return err
+	}
+
+	if err := d.Set("peer_cidr", p.PeerCidrIpv4Block); err != nil {
+		return err
+	}
+
+	if err := d.Set("peer_region", p.PeerRegion); err != nil {
+		return err
+	}
+
+	if err := d.Set("status", p.Status); err != nil {
+		return err
+	}
+
+	if err := d.Set("status_message", p.StatusMessage); err != nil {
+		return err
+	}
+
+	if err := d.Set("cloud_peering_id", p.CloudPeeringID); err != nil {
+		return err
+	}
+
+	if err := d.Set("cloud_account_id", p.CloudAccountID); err != nil {
+		return err
+	}
+
+	return d.Set("cloud_vpc_id", p.CloudVpcID)
+}
@@ -0,0 +1,203 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVPCPeering(t *testing.T) {
+	m, p := mockProvider(t)
+
+	peerings := map[string]ccx.VPCPeering{}
+	created := 0
+
+	m.vpcPeering.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, v ccx.VPCPeering) (*ccx.VPCPeering, error) {
+		created++
+		v.ID = fmt.Sprintf("peering-%d", created)
+		v.PeerRegion = "eu-north-1"
+		v.Status = "pending-acceptance"
+		v.CloudPeeringID = fmt.Sprintf("pcx-%d", created)
+		v.CloudAccountID = "210987654321"
+		v.CloudVpcID = "vpc-9f8e7d6c"
+		peerings[v.ID] = v
+
+		return &v, nil
+	}).Twice()
+	m.vpcPeering.EXPECT().Read(mock.Anything, "vpc-1", mock.Anything).RunAndReturn(func(_ context.Context, _, id string) (*ccx.VPCPeering, error) {
+		v, ok := peerings[id]
+		if !ok {
+			return nil, ccx.ErrResourceNotFound
+		}
+
+		return &v, nil
+	})
+	m.vpcPeering.EXPECT().Delete(mock.Anything, "vpc-1", mock.Anything).RunAndReturn(func(_ context.Context, _, id string) error {
+		delete(peerings, id)
+
+		return nil
+	}).Twice()
+
+	config := `
+resource "ccx_vpc_peering" "app" {
+  vpc_id                = "vpc-1"
+  peer_cloud_account_id = "123456789012"
+  peer_vpc_id           = "vpc-0a1b2c3d"
+  peer_cidr             = "%s"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"ccx": func() (*schema.Provider, error) {
+				return p, nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(config, "10.20.0.0/33"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid CIDR`),
+			},
+			{
+				Config: fmt.Sprintf(config, "10.20.0.0/16"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ccx_vpc_peering.app", "id", "vpc-1/peering-1"),
+					resource.TestCheckResourceAttr("ccx_vpc_peering.app", "peer_region", "eu-north-1"),
+					resource.TestCheckResourceAttr("ccx_vpc_peering.app", "status", "pending-acceptance"),
+					resource.TestCheckResourceAttr("ccx_vpc_peering.app", "cloud_peering_id", "pcx-1"),
+					resource.TestCheckResourceAttr("ccx_vpc_peering.app", "cloud_account_id", "210987654321"),
+					resource.TestCheckResourceAttr("ccx_vpc_peering.app", "cloud_vpc_id", "vpc-9f8e7d6c"),
+				),
+			},
+			{
+				// the status changes once the peering is accepted on the peer side
+				PreConfig: func() {
+					v := peerings["peering-1"]
+					v.Status = "active"
+					peerings["peering-1"] = v
+				},
+				Config: fmt.Sprintf(config, "10.20.0.0/16"),
+				Check:  resource.TestCheckResourceAttr("ccx_vpc_peering.app", "status", "active"),
+			},
+			{
+				ResourceName:      "ccx_vpc_peering.app",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "ccx_vpc_peering.app",
+				ImportState:   true,
+				ImportStateId: "vpc-1/peering-9",
+				ExpectError:   regexp.MustCompile(`vpc peering "vpc-1/peering-9" not found`),
+			},
+			{
+				// the peer cidr requires a new peering
+				Config: fmt.Sprintf(config, "10.30.0.0/16"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ccx_vpc_peering.app", "id", "vpc-1/peering-2"),
+					resource.TestCheckResourceAttr("ccx_vpc_peering.app", "peer_cidr", "10.30.0.0/16"),
+				),
+			},
+		},
+	})
+
+	require.Empty(t, peerings)
+}
+
+func Test_parseVPCPeeringID(t *testing.T) {
+	vpcID, id, err := parseVPCPeeringID("vpc-1/peering-1")
+	require.NoError(t, err)
+	require.Equal(t, "vpc-1", vpcID)
+	require.Equal(t, "peering-1", id)
+
+	for _, s := range []string{"vpc-1", "vpc-1/", "/peering-1", ""} {
+		_, _, err := parseVPCPeeringID(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestVPCPeering_Failed(t *testing.T) {
+	m, p := mockProvider(t)
+
+	peerings := map[string]ccx.VPCPeering{}
+	created := 0
+
+	m.vpcPeering.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, v ccx.VPCPeering) (*ccx.VPCPeering, error) {
+		created++
+		v.ID = fmt.Sprintf("peering-%d", created)
+		v.PeerRegion = "eu-north-1"
+
+		if created == 1 {
+			v.Status = "failed"
+			v.StatusMessage = "the peer vpc was not found"
+			peerings[v.ID] = v
+
+			return &v, fmt.Errorf("awaiting vpc peering %s: vpc peering failed with status %s: %s", v.ID, v.Status, v.StatusMessage)
+		}
+
+		v.Status = "pending-acceptance"
+		v.CloudPeeringID = fmt.Sprintf("pcx-%d", created)
+		v.CloudAccountID = "210987654321"
+		v.CloudVpcID = "vpc-9f8e7d6c"
+		peerings[v.ID] = v
+
+		return &v, nil
+	}).Twice()
+	m.vpcPeering.EXPECT().Read(mock.Anything, "vpc-1", mock.Anything).RunAndReturn(func(_ context.Context, _, id string) (*ccx.VPCPeering, error) {
+		v, ok := peerings[id]
+		if !ok {
+			return nil, ccx.ErrResourceNotFound
+		}
+
+		return &v, nil
+	})
+	m.vpcPeering.EXPECT().Delete(mock.Anything, "vpc-1", mock.Anything).RunAndReturn(func(_ context.Context, _, id string) error {
+		delete(peerings, id)
+
+		return nil
+	}).Twice()
+
+	config := `
+resource "ccx_vpc_peering" "app" {
+  vpc_id                = "vpc-1"
+  peer_cloud_account_id = "123456789012"
+  peer_vpc_id           = "vpc-0a1b2c3d"
+  peer_cidr             = "10.20.0.0/16"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"ccx": func() (*schema.Provider, error) {
+				return p, nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`failed with status failed: the peer vpc was not found`),
+			},
+			{
+				// the failed peering is stored, so it is replaced
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ccx_vpc_peering.app", "id", "vpc-1/peering-2"),
+					resource.TestCheckResourceAttr("ccx_vpc_peering.app", "status", "pending-acceptance"),
+					resource.TestCheckResourceAttr("ccx_vpc_peering.app", "status_message", ""),
+				),
+			},
+		},
+	})
+
+	require.Empty(t, peerings)
+}
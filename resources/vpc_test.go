@@ -0,0 +1,240 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/severalnines/terraform-provider-ccx/internal/ccx"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVPC(t *testing.T) {
+	m, p := mockProvider(t)
+
+	vpcs := map[string]ccx.VPC{}
+	created := 0
+
+	m.vpc.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, v ccx.VPC) (*ccx.VPC, error) {
+		created++
+		v.ID = fmt.Sprintf("vpc-%d", created)
+		v.CloudSpace = "default"
+		vpcs[v.ID] = v
+
+		return &v, nil
+	}).Twice()
+	m.vpc.EXPECT().Read(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, id string) (*ccx.VPC, error) {
+		v, ok := vpcs[id]
+		if !ok {
+			return nil, ccx.ErrResourceNotFound
+		}
+
+		return &v, nil
+	})
+	m.vpc.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, v ccx.VPC) (*ccx.VPC, error) {
+		vpcs[v.ID] = v
+
+		return &v, nil
+	}).Once()
+	m.vpc.EXPECT().Delete(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, id string) error {
+		delete(vpcs, id)
+
+		return nil
+	}).Twice()
+	m.datastore.EXPECT().ListInVPC(mock.Anything, mock.Anything).Return(nil, nil)
+
+	config := `
+resource "ccx_vpc" "venus" {
+  name           = "%s"
+  cloud_provider = "aws"
+  cloud_region   = "%s"
+  ipv4_cidr      = "10.10.0.0/16"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"ccx": func() (*schema.Provider, error) {
+				return p, nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, "venus", "eu-north-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ccx_vpc.venus", "id", "vpc-1"),
+					resource.TestCheckResourceAttr("ccx_vpc.venus", "cloud_space", "default"),
+				),
+			},
+			{
+				// the name is updated in place
+				Config: fmt.Sprintf(config, "mars", "eu-north-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ccx_vpc.venus", "id", "vpc-1"),
+					resource.TestCheckResourceAttr("ccx_vpc.venus", "name", "mars"),
+				),
+			},
+			{
+				ResourceName:      "ccx_vpc.venus",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// the region requires a new vpc
+				Config: fmt.Sprintf(config, "mars", "eu-west-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ccx_vpc.venus", "id", "vpc-2"),
+					resource.TestCheckResourceAttr("ccx_vpc.venus", "cloud_region", "eu-west-1"),
+				),
+			},
+		},
+	})
+}
+
+func TestVPC_CIDR(t *testing.T) {
+	m, p := mockProvider(t)
+
+	vpcs := map[string]ccx.VPC{}
+
+	m.vpc.EXPECT().List(mock.Anything).Return([]ccx.VPC{
+		{ID: "vpc-mars", Name: "mars", CloudProvider: "aws", Region: "eu-north-1", CidrIpv4Block: "10.10.0.0/16"},
+	}, nil)
+	m.vpc.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, v ccx.VPC) (*ccx.VPC, error) {
+		v.ID = "vpc-1"
+		vpcs[v.ID] = v
+
+		return &v, nil
+	}).Once()
+	m.vpc.EXPECT().Read(mock.Anything, "vpc-1").RunAndReturn(func(_ context.Context, id string) (*ccx.VPC, error) {
+		v := vpcs[id]
+		return &v, nil
+	})
+	m.vpc.EXPECT().Delete(mock.Anything, "vpc-1").Return(nil).Once()
+	m.datastore.EXPECT().ListInVPC(mock.Anything, mock.Anything).Return(nil, nil)
+
+	config := `
+resource "ccx_vpc" "venus" {
+  name               = "venus"
+  cloud_provider     = "aws"
+  cloud_region       = "eu-north-1"
+  ipv4_cidr          = "%s"
+  cidr_overlap_check = "%s"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"ccx": func() (*schema.Provider, error) {
+				return p, nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(config, "10.10.0.0/33", "off"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid CIDR`),
+			},
+			{
+				Config:      fmt.Sprintf(config, "10.0.0.0/8", "off"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must be between /16 and /24`),
+			},
+			{
+				Config:      fmt.Sprintf(config, "2001:db8::/48", "off"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`is not an IPv4 CIDR`),
+			},
+			{
+				Config:      fmt.Sprintf(config, "10.10.0.0/16", "always"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid cidr_overlap_check`),
+			},
+			{
+				Config:      fmt.Sprintf(config, "10.10.128.0/20", "error"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`overlaps with the CIDRs of the VPCs: mars \(vpc-mars, 10.10.0.0/16\)`),
+			},
+			{
+				// with warn, the VPC is created
+				Config: fmt.Sprintf(config, "10.10.128.0/20", "warn"),
+				Check:  resource.TestCheckResourceAttr("ccx_vpc.venus", "ipv4_cidr", "10.10.128.0/20"),
+			},
+			{
+				// the same CIDR with host bits does not cause a diff
+				Config:   fmt.Sprintf(config, "10.10.128.1/20", "warn"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestVPC_DeleteAttached(t *testing.T) {
+	m, p := mockProvider(t)
+
+	venus := ccx.VPC{ID: "vpc-1", Name: "venus", CloudProvider: "aws", Region: "eu-north-1", CidrIpv4Block: "10.10.0.0/16"}
+
+	m.vpc.EXPECT().Create(mock.Anything, mock.Anything).Return(&venus, nil).Once()
+	m.vpc.EXPECT().Read(mock.Anything, "vpc-1").Return(&venus, nil)
+	m.vpc.EXPECT().Delete(mock.Anything, "vpc-1").Return(nil).Once()
+
+	// the number of times the datastore is listed before it is deleted, -1 while it is not being deleted
+	var remaining atomic.Int32
+
+	remaining.Store(-1)
+
+	m.datastore.EXPECT().ListInVPC(mock.Anything, "vpc-1").RunAndReturn(func(context.Context, string) ([]ccx.Datastore, error) {
+		if remaining.Load() == 0 {
+			return nil, nil
+		}
+
+		remaining.Add(-1)
+
+		return []ccx.Datastore{{ID: "datastore-1", Name: "luna", VpcUUID: "vpc-1"}}, nil
+	})
+
+	config := `
+resource "ccx_vpc" "venus" {
+  name           = "venus"
+  cloud_provider = "aws"
+  cloud_region   = "eu-north-1"
+  ipv4_cidr      = "10.10.0.0/16"
+
+  timeouts {
+    delete = "%s"
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"ccx": func() (*schema.Provider, error) {
+				return p, nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, "100ms"),
+			},
+			{
+				// the datastore is not deleted within the timeout
+				Config:      fmt.Sprintf(config, "100ms"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`(?s)datastores are still attached to it:\s+luna \(datastore-1\)`),
+			},
+			{
+				// the datastore is being deleted, the vpc is deleted once it is gone
+				PreConfig: func() {
+					remaining.Store(3)
+				},
+				Config: fmt.Sprintf(config, "1m"),
+			},
+		},
+	})
+}